@@ -9,9 +9,7 @@ package getopts
 
 import (
 	"fmt"
-	"log"
 	"os"
-	"runtime"
 	"sync"
 )
 
@@ -35,67 +33,60 @@ type (
 // If the `ShowHelp()` function returns a non `nil` error value the
 // getopts processing is aborted.
 //
+// A [TUsage] instance can be used to print a help text generated from
+// the expected options.
+//
 // Note: This variable must be setup before the [Get] function is called.
 var HelpShower IHelpShower
 
+// `ExitFunc` is called by [Get] with the exit code `1` if the
+// `HelpShower.ShowHelp()` method returns an error.
+//
+// It defaults to `os.Exit()`. Setting it to `nil` (or to a function
+// that returns) keeps the running application alive: the help request
+// is reported as an error wrapping [ErrHelpRequested] instead, so the
+// caller can decide whether to terminate, and deferred cleanups (or
+// tests) still run.
+var ExitFunc func(int) = os.Exit
+
 // --------------------------------------------------------------------
 // Internal functions
 
 var (
-	// Internal flag signalling whether we're in testing/debugging mode:
-	gSomeTestsAreRunning bool
+	// Internal default parser to make it accessible for the public
+	// `Get()` function. It is initialised automatically by the
+	// getopts' `realInit()` function.
+	gParser *TParser
 
-	// Barrier for concurrent tests
+	// Guard of the internal default parser
 	gMtx sync.Mutex
 )
 
 // `init()` automatically initialises the command-line argument parser
 // and sets up the associated parser for the arguments.
+//
+// To process other argument lists (e.g. in tests) see [NewParser]
+// and [Parse].
 func init() {
-	args := os.Args
-
-	//
-	//TODO: split os.Args into option groups using a pattern like:
-	// 	([\p{L}]+(\s+((\-+[\p{L}]+)\s*)+)+)
-	//
-
-	// This env var is set by `go -test`:
-	if "true" == os.Getenv("testing") {
-		// Apparently we're testing or debugging
-		gSomeTestsAreRunning = true
-
-		args = []string{
-			"testingApplication",
-			`-a`, // Flag option
-			`-i`, // Error: intended with argument => ignored
-			`--infile`, `config.in`,
-			`--help`, // Flag option
-		}
-	}
-
-	realInit(args)
+	// NOTE: Commandlines consisting of several option groups
+	// (one per subcommand word) are handled by `TCommand.Run()`.
+	realInit(os.Args)
 } // init()
 
 // `(realInit)` initialises the commandline argument parser.
 //
-// The function checks if at least one argument is passed and then
-// initialises an arguments map to store the command-line arguments
-// and options. It then gets the command-line arguments without the
-// app's path/name, iterates through them, and adds the argument and
-// their corresponding options to the `arguments` map. Finally, it
-// initialises the internal iterator `gIterator` with the arguments map.
+// The function sets up the internal default parser `gParser` used by
+// the public [Get] function with the given list of commandline options
+// and arguments.
 //
 // Parameters:
 //   - `aArgList`: A list of commandline options and arguments.
 func realInit(aArgList []string) {
-	if gSomeTestsAreRunning {
-		gMtx.Lock()
-		defer gMtx.Unlock()
-	}
-	oal := newOptArgList(aArgList)
+	gMtx.Lock()
+	defer gMtx.Unlock()
 
-	// Set up the global/internal iterator:
-	newIterator(oal)
+	// Set up the global/internal parser:
+	gParser = NewParser(aArgList, "")
 } // realInit()
 
 // --------------------------------------------------------------------
@@ -103,14 +94,24 @@ func realInit(aArgList []string) {
 
 // `Get()` retrieves the next commandline option and its argument.
 //
-// The function uses an internal iterator to retrieve the next option and
-// its argument. If the iterator has no more items, it returns `false` for
-// `rOK`. The retrieved option and argument are returned as `rOpt` and
+// The function uses an internal default parser (working on the
+// application's commandline) to retrieve the next option and its
+// argument. If the parser has no more items, it returns `false` for
+// `rMore`. The retrieved option and argument are returned as `rOpt` and
 // `rArg` respectively.
 //
-// The `aPattern` parameter is used to set up the internal iterator
+// The `aPattern` parameter is used to set up the internal parser
 // to know which options to expect/accept.
 //
+// The internal parser is guarded, so this and the other public
+// functions may be called from several goroutines; the [HelpShower]
+// called by this function must not call any of them itself, though.
+// Since the iteration is shared, reading the options once by [Result]
+// is the better choice for concurrent use.
+//
+// To process other argument lists than the application's commandline
+// see [NewParser].
+//
 // Parameters:
 //   - `aPattern`: The pattern declaring which commandline options to expect.
 //
@@ -119,30 +120,145 @@ func realInit(aArgList []string) {
 //   - `rArg`: The option's argument in the iteration.
 //   - `rMore`: Indicator for whether there are more options to come.
 func Get(aPattern string) (rOpt string, rArg TArg, rMore bool) {
-	o, rArg, rMore := gIterator.setPattern(aPattern).Next()
-	if `` == o {
-		// This might happen if the last commandline option is
-		// invalid (i.e. not defined in `aPattern` or missing
-		// its required argument).
-		rOpt = string(`?`)
-	} else {
-		switch o {
-		case `h`, `-help`:
-			if nil != HelpShower {
-				if err := HelpShower.ShowHelp(); nil != err {
-					// Perhaps somebody needs time?
-					runtime.Gosched()
-					// And here we go ...
-					log.Fatalln(err.Error())
-				}
-			}
-		}
-		rOpt = string(o)
-	}
+	gMtx.Lock()
+	defer gMtx.Unlock()
 
-	return
+	return gParser.setPattern(aPattern).Get()
 } // Get()
 
+// `Err()` returns all problems found with the application's commandline
+// options according to the pattern last used with [Get].
+//
+// Returns:
+//   - `error`: `nil` if there are no problems, or all errors joined.
+func Err() error {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.Err()
+} // Err()
+
+// `Errors()` returns the list of problems found with the application's
+// commandline options according to the pattern last used with [Get].
+//
+// Returns:
+//   - `[]error`: The list of errors (empty if there are none).
+func Errors() []error {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.Errors()
+} // Errors()
+
+// `IsHelp()` returns whether a help option (`-h` or `--help`) was given
+// on the application's commandline according to the pattern last used
+// with [Get].
+//
+// Returns:
+//   - `bool`: Indicator for whether help was requested.
+func IsHelp() bool {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.IsHelp()
+} // IsHelp()
+
+// `LoadConfig()` reads the options of the application's commandline
+// from a configuration file.
+//
+// See [TParser.LoadConfig] for details.
+//
+// Parameters:
+//   - `aOpt`: The option's name naming the configuration file.
+//   - `aDefaultPath`: The file to use if the option is not given.
+//
+// Returns:
+//   - `error`: A possible error reading or parsing the file.
+func LoadConfig(aOpt, aDefaultPath string) error {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.LoadConfig(aOpt, aDefaultPath)
+} // LoadConfig()
+
+// `Operands()` returns the positional arguments of the application's
+// commandline according to the pattern last used with [Get].
+//
+// Returns:
+//   - `[]string`: The list of operands (empty if there are none).
+func Operands() []string {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.Operands()
+} // Operands()
+
+// `Result()` processes the application's commandline according to the
+// given pattern and returns the outcome.
+//
+// The result is meant to be set up once (e.g. in `main()`) and then
+// shared: its lookups are safe for concurrent use (see [TResult]).
+// Other than [Get] no option is handled by the processing, i.e. neither
+// a help text is shown nor is the application terminated.
+//
+// Parameters:
+//   - `aPattern`: The pattern declaring which commandline options to expect.
+//
+// Returns:
+//   - `TResult`: The outcome of the processing.
+func Result(aPattern string) TResult {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.setPattern(aPattern).Result()
+} // Result()
+
+// `SetEnvPrefix()` sets up an automatic mapping of environment variables
+// to the options of the application's commandline.
+//
+// See [TParser.SetEnvPrefix] for details.
+//
+// Parameters:
+//   - `aPrefix`: The prefix of the environment variables' names.
+func SetEnvPrefix(aPrefix string) {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	gParser.SetEnvPrefix(aPrefix)
+} // SetEnvPrefix()
+
+// `Source()` returns where the value of the given option of the
+// application's commandline came from.
+//
+// Parameters:
+//   - `aOpt`: The option's name as used in the pattern.
+//
+// Returns:
+//   - `TSource`: The option's source.
+func Source(aOpt string) TSource {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.Source(aOpt)
+} // Source()
+
+// `Validate()` checks the options of the application's commandline
+// all at once.
+//
+// See [TParser.Validate] for details.
+//
+// Parameters:
+//   - `aRules`: The rules to check.
+//
+// Returns:
+//   - `error`: `nil` if there are no problems, or all errors joined.
+func Validate(aRules ...TRule) error {
+	gMtx.Lock()
+	defer gMtx.Unlock()
+
+	return gParser.Validate(aRules...)
+} // Validate()
+
 func MySetup(aPattern string) {
 	var (
 		b bool
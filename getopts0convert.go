@@ -0,0 +1,292 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The multipliers of the units accepted by `TArg.SizeE()`.
+	gSizeUnits = map[string]float64{
+		"":    1,
+		"b":   1,
+		"k":   1e3,
+		"kb":  1e3,
+		"ki":  1 << 10,
+		"kib": 1 << 10,
+		"m":   1e6,
+		"mb":  1e6,
+		"mi":  1 << 20,
+		"mib": 1 << 20,
+		"g":   1e9,
+		"gb":  1e9,
+		"gi":  1 << 30,
+		"gib": 1 << 30,
+		"t":   1e12,
+		"tb":  1e12,
+		"ti":  1 << 40,
+		"tib": 1 << 40,
+		"p":   1e15,
+		"pb":  1e15,
+		"pi":  1 << 50,
+		"pib": 1 << 50,
+	}
+
+	// The layouts accepted by `TArg.TimeE()` in the order they are tried.
+	gTimeLayouts = []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		time.DateOnly,
+	}
+)
+
+// --------------------------------------------------------------------
+// TArg conversion methods
+
+// `Duration()` returns the argument's value as a time duration.
+//
+// In case the argument can't be converted to a duration the method's
+// result will be `0` (zero).
+//
+// Returns:
+//   - `time.Duration`: The argument's value as a duration.
+func (a TArg) Duration() time.Duration {
+	result, _ := a.DurationE()
+
+	return result
+} // Duration()
+
+// `DurationE()` returns the argument's value as a time duration.
+//
+// The argument is expected in the format used by `time.ParseDuration()`,
+// e.g. `30s`, `1h30m`, or `250ms`.
+//
+// Returns:
+//   - `time.Duration`: The argument's value as a duration.
+//   - `error`: An [ErrInvalidValue] if the argument is not a duration.
+func (a TArg) DurationE() (time.Duration, error) {
+	d, err := time.ParseDuration(string(a))
+	if nil != err {
+		return time.Duration(0), ErrInvalidValue{Arg: string(a), Kind: "duration", Err: err}
+	}
+
+	return d, nil
+} // DurationE()
+
+// `Int64()` returns the argument's value as a 64bit integer.
+//
+// In case the argument can't be converted to an integer the method's
+// result will be `int64(0)` (zero).
+//
+// Returns:
+//   - `int64`: The argument's value as a 64bit integer.
+func (a TArg) Int64() int64 {
+	result, _ := a.Int64E()
+
+	return result
+} // Int64()
+
+// `Int64E()` returns the argument's value as a 64bit integer.
+//
+// Other than [TArg.IntE] this method accepts the base prefixes used by
+// Go's integer literals, i.e. `0x` (hexadecimal), `0o` or `0` (octal),
+// and `0b` (binary). Underscores may be used as digit separators.
+//
+// Returns:
+//   - `int64`: The argument's value as a 64bit integer.
+//   - `error`: An [ErrInvalidValue] if the argument is not an integer.
+func (a TArg) Int64E() (int64, error) {
+	i64, err := strconv.ParseInt(string(a), 0, 64)
+	if nil != err {
+		return int64(0), ErrInvalidValue{Arg: string(a), Kind: "integer", Err: err}
+	}
+
+	return i64, nil
+} // Int64E()
+
+// `Size()` returns the argument's value as a number of bytes.
+//
+// In case the argument can't be converted to a size the method's result
+// will be `uint64(0)` (zero).
+//
+// Returns:
+//   - `uint64`: The argument's value as a number of bytes.
+func (a TArg) Size() uint64 {
+	result, _ := a.SizeE()
+
+	return result
+} // Size()
+
+// `SizeE()` returns the argument's value as a number of bytes.
+//
+// The argument consists of a (possibly fractional) number followed by
+// an optional unit: `B` for bytes, `K`, `KB`, `M`, `MB`, `G`, `GB`, `T`,
+// `TB`, `P`, `PB` for decimal multiples (powers of 1000), or `Ki`, `KiB`,
+// `Mi`, `MiB`, `Gi`, `GiB`, `Ti`, `TiB`, `Pi`, `PiB` for binary multiples
+// (powers of 1024). The units are case insensitive and may be separated
+// from the number by a space, so `10MiB`, `10 mib`, and `10485760` are
+// all the same size.
+//
+// Returns:
+//   - `uint64`: The argument's value as a number of bytes.
+//   - `error`: An [ErrInvalidValue] if the argument is not a size.
+func (a TArg) SizeE() (uint64, error) {
+	s := strings.TrimSpace(string(a))
+	pos := len(s)
+	for (0 < pos) && ((s[pos-1] < '0') || (s[pos-1] > '9')) && ('.' != s[pos-1]) {
+		pos--
+	}
+	mult, ok := gSizeUnits[strings.ToLower(strings.TrimSpace(s[pos:]))]
+	if !ok {
+		return uint64(0), ErrInvalidValue{Arg: string(a), Kind: "size"}
+	}
+
+	f64, err := strconv.ParseFloat(strings.TrimSpace(s[:pos]), 64)
+	if nil != err {
+		return uint64(0), ErrInvalidValue{Arg: string(a), Kind: "size", Err: err}
+	}
+	f64 *= mult
+	if (0 > f64) || (f64 != f64) || (f64 >= math.MaxUint64) {
+		return uint64(0), ErrInvalidValue{Arg: string(a), Kind: "size", Err: strconv.ErrRange}
+	}
+
+	return uint64(f64), nil
+} // SizeE()
+
+// `Time()` returns the argument's value as a point in time.
+//
+// In case the argument can't be converted to a time the method's result
+// will be the zero time.
+//
+// Returns:
+//   - `time.Time`: The argument's value as a point in time.
+func (a TArg) Time() time.Time {
+	result, _ := a.TimeE()
+
+	return result
+} // Time()
+
+// `TimeE()` returns the argument's value as a point in time.
+//
+// The argument is expected either in RFC3339 format (e.g.
+// `2024-08-09T12:34:56+02:00`), as date and time without a time zone
+// (`2024-08-09T12:34:56` or `2024-08-09 12:34:56`), or as a date only
+// (`2024-08-09`). Values without a time zone are considered UTC.
+//
+// Returns:
+//   - `time.Time`: The argument's value as a point in time.
+//   - `error`: An [ErrInvalidValue] if the argument is not a time.
+func (a TArg) TimeE() (time.Time, error) {
+	for _, layout := range gTimeLayouts {
+		if t, err := time.Parse(layout, string(a)); nil == err {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidValue{Arg: string(a), Kind: "time"}
+} // TimeE()
+
+// `Uint()` returns the argument's value as an unsigned integer.
+//
+// In case the argument can't be converted to an unsigned integer the
+// method's result will be `uint(0)` (zero).
+//
+// Returns:
+//   - `uint`: The argument's value as an unsigned integer.
+func (a TArg) Uint() uint {
+	result, _ := a.UintE()
+
+	return result
+} // Uint()
+
+// `UintE()` returns the argument's value as an unsigned integer.
+//
+// The base prefixes are accepted as described with [TArg.Int64E].
+//
+// Returns:
+//   - `uint`: The argument's value as an unsigned integer.
+//   - `error`: An [ErrInvalidValue] if the argument is not an unsigned integer.
+func (a TArg) UintE() (uint, error) {
+	u64, err := strconv.ParseUint(string(a), 0, 0)
+	if nil != err {
+		return uint(0), ErrInvalidValue{Arg: string(a), Kind: "unsigned integer", Err: err}
+	}
+
+	return uint(u64), nil
+} // UintE()
+
+// `Uint64()` returns the argument's value as a 64bit unsigned integer.
+//
+// In case the argument can't be converted to an unsigned integer the
+// method's result will be `uint64(0)` (zero).
+//
+// Returns:
+//   - `uint64`: The argument's value as a 64bit unsigned integer.
+func (a TArg) Uint64() uint64 {
+	result, _ := a.Uint64E()
+
+	return result
+} // Uint64()
+
+// `Uint64E()` returns the argument's value as a 64bit unsigned integer.
+//
+// The base prefixes are accepted as described with [TArg.Int64E].
+//
+// Returns:
+//   - `uint64`: The argument's value as a 64bit unsigned integer.
+//   - `error`: An [ErrInvalidValue] if the argument is not an unsigned integer.
+func (a TArg) Uint64E() (uint64, error) {
+	u64, err := strconv.ParseUint(string(a), 0, 64)
+	if nil != err {
+		return uint64(0), ErrInvalidValue{Arg: string(a), Kind: "unsigned integer", Err: err}
+	}
+
+	return u64, nil
+} // Uint64E()
+
+// `URL()` returns the argument's value as a parsed URL.
+//
+// In case the argument can't be parsed the method's result will be `nil`.
+//
+// Returns:
+//   - `*url.URL`: The argument's value as an URL.
+func (a TArg) URL() *url.URL {
+	result, _ := a.URLE()
+
+	return result
+} // URL()
+
+// `URLE()` returns the argument's value as a parsed URL.
+//
+// An empty argument is considered an error.
+//
+// Returns:
+//   - `*url.URL`: The argument's value as an URL.
+//   - `error`: An [ErrInvalidValue] if the argument is not an URL.
+func (a TArg) URLE() (*url.URL, error) {
+	if "" == a {
+		return nil, ErrInvalidValue{Arg: string(a), Kind: "URL"}
+	}
+	u, err := url.Parse(string(a))
+	if nil != err {
+		return nil, ErrInvalidValue{Arg: string(a), Kind: "URL", Err: err}
+	}
+
+	return u, nil
+} // URLE()
+
+/* _EoF_ */
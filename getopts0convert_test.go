@@ -0,0 +1,195 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_TArg_DurationE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    time.Duration
+		wantErr bool
+	}{
+		{"0", TArg(""), 0, true},
+		{"1", TArg("30s"), 30 * time.Second, false},
+		{"2", TArg("1h30m"), 90 * time.Minute, false},
+		{"3", TArg("30"), 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.DurationE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.DurationE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.DurationE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_DurationE()
+
+func Test_TArg_Int64E(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    int64
+		wantErr bool
+	}{
+		{"0", TArg(""), 0, true},
+		{"1", TArg("-1234567890123"), -1234567890123, false},
+		{"2", TArg("0x1F"), 31, false},
+		{"3", TArg("0o17"), 15, false},
+		{"4", TArg("017"), 15, false},
+		{"5", TArg("0b101"), 5, false},
+		{"6", TArg("0xZZ"), 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.Int64E()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.Int64E() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.Int64E() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_Int64E()
+
+func Test_TArg_SizeE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    uint64
+		wantErr bool
+	}{
+		{"0", TArg(""), 0, true},
+		{"1", TArg("1024"), 1024, false},
+		{"2", TArg("10MiB"), 10 << 20, false},
+		{"3", TArg("10 mb"), 10_000_000, false},
+		{"4", TArg("1.5KiB"), 1536, false},
+		{"5", TArg("2G"), 2_000_000_000, false},
+		{"6", TArg("12B"), 12, false},
+		{"7", TArg("10XB"), 0, true},
+		{"8", TArg("-1K"), 0, true},
+		{"9", TArg("MiB"), 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.SizeE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.SizeE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.SizeE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_SizeE()
+
+func Test_TArg_TimeE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    time.Time
+		wantErr bool
+	}{
+		{"0", TArg(""), time.Time{}, true},
+		{"1", TArg("2024-08-09"), time.Date(2024, 8, 9, 0, 0, 0, 0, time.UTC), false},
+		{"2", TArg("2024-08-09T12:34:56Z"), time.Date(2024, 8, 9, 12, 34, 56, 0, time.UTC), false},
+		{"3", TArg("2024-08-09 12:34:56"), time.Date(2024, 8, 9, 12, 34, 56, 0, time.UTC), false},
+		{"4", TArg("2024-08-09T12:34:56+02:00"), time.Date(2024, 8, 9, 10, 34, 56, 0, time.UTC), false},
+		{"5", TArg("09.08.2024"), time.Time{}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.TimeE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.TimeE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("%q: TArg.TimeE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_TimeE()
+
+func Test_TArg_UintE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    uint
+		wantErr bool
+	}{
+		{"0", TArg(""), 0, true},
+		{"1", TArg("42"), 42, false},
+		{"2", TArg("0xff"), 255, false},
+		{"3", TArg("-1"), 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.UintE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.UintE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.UintE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_UintE()
+
+func Test_TArg_URLE(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      TArg
+		wantHost string
+		wantErr  bool
+	}{
+		{"0", TArg(""), "", true},
+		{"1", TArg("https://example.com:8080/path"), "example.com:8080", false},
+		{"2", TArg("http://[::1"), "", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.URLE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.URLE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if nil != got && got.Host != tt.wantHost {
+				t.Errorf("%q: TArg.URLE() = %v, want %v",
+					tt.name, got.Host, tt.wantHost)
+			}
+		})
+	}
+} // Test_TArg_URLE()
+
+/* _EoF_ */
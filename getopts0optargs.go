@@ -10,6 +10,7 @@ package getopts
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
@@ -41,12 +42,10 @@ type (
 
 // `Bool()` returns the argument's value as a boolean value.
 //
-// `0`, `f`, `F`, `n`, and `N` are considered `false` while
-// `1`, `t`, `T`, `y`, `Y`, `j`, `J`, `o`, `O` are considered `true`.
-//
-// This method actually checks only the first character of a option's
-// argument so one can write e.g. "false" or "NO" (for a `false` result),
-// or "True" or "yes" (for a `true` result).
+// The words `0`, `f`, `false`, `n`, `no`, `off`, `nein`, and `non`
+// are considered `false` while `1`, `t`, `true`, `y`, `yes`, `on`,
+// `j`, `ja`, `o`, and `oui` are considered `true` (regardless of
+// their case, i.e. e.g. "NO" and "True" are accepted as well).
 //
 // If the argument is empty or holds any other value than the ones
 // mentioned above, then the method's result will be `false`.
@@ -54,19 +53,32 @@ type (
 // Returns:
 // - `bool`: The argument's value as a Boolean.
 func (a TArg) Bool() bool {
-	if 0 < len(a) {
-		switch a[:1] {
-		case `0`, `f`, `F`, `n`, `N`:
-			return false
+	result, _ := a.BoolE()
 
-		case `1`, `t`, `T`, `y`, `Y`, `j`, `J`, `o`, `O`:
-			// True, Yes (English), Ja (German), Oui (French)`
-			return true
-		}
+	return result
+} // Bool()
+
+// `BoolE()` returns the argument's value as a boolean value.
+//
+// Other than [TArg.Bool] this method returns an error if the argument
+// is empty or is not one of the words documented there.
+//
+// Returns:
+//   - `bool`: The argument's value as a Boolean.
+//   - `error`: An [ErrInvalidValue] if the argument is not a boolean.
+func (a TArg) BoolE() (bool, error) {
+	switch strings.ToLower(string(a)) {
+	case `0`, `f`, `false`, `n`, `no`, `off`,
+		`nein`, `non`: // German, French
+		return false, nil
+
+	case `1`, `t`, `true`, `y`, `yes`, `on`,
+		`j`, `ja`, `o`, `oui`: // German, French
+		return true, nil
 	}
 
-	return false
-} // Bool()
+	return false, ErrInvalidValue{Arg: string(a), Kind: "boolean"}
+} // BoolE()
 
 // `Equal()` checks if this argument is equal to another one.
 //
@@ -91,17 +103,31 @@ func (a TArg) Equal(aArg TArg) bool {
 // Returns:
 // - `float64`: The argument's value as a 64bit floating point.
 func (a TArg) Float() float64 {
-	if "" == string(a) {
-		return float64(0.0)
-	}
+	result, _ := a.FloatE()
+
+	return result
+} // Float()
 
-	if f64, err := strconv.ParseFloat(string(a), 64); (nil == err) && (f64 == f64) {
+// `FloatE()` returns the argument's value as a 64bit floating point.
+//
+// Other than [TArg.Float] this method returns an error if the argument
+// can't be converted (including the case of a `NaN` value).
+//
+// Returns:
+//   - `float64`: The argument's value as a 64bit floating point.
+//   - `error`: An [ErrInvalidValue] if the argument is not a number.
+func (a TArg) FloatE() (float64, error) {
+	f64, err := strconv.ParseFloat(string(a), 64)
+	if nil != err {
+		return float64(0.0), ErrInvalidValue{Arg: string(a), Kind: "floating point", Err: err}
+	}
+	if f64 != f64 {
 		// for NaN the inequality comparison with itself returns true
-		return f64
+		return float64(0.0), ErrInvalidValue{Arg: string(a), Kind: "floating point"}
 	}
 
-	return float64(0.0)
-} // Float()
+	return f64, nil
+} // FloatE()
 
 // `Int()` returns the argument's value as an integer.
 //
@@ -111,16 +137,27 @@ func (a TArg) Float() float64 {
 // Returns:
 // - `int`: The argument's value as an integer.
 func (a TArg) Int() int {
-	if "" == string(a) {
-		return int(0)
-	}
+	result, _ := a.IntE()
+
+	return result
+} // Int()
 
-	if i64, err := strconv.ParseInt(string(a), 10, 0); nil == err {
-		return int(i64)
+// `IntE()` returns the argument's value as an integer.
+//
+// Other than [TArg.Int] this method returns an error if the argument
+// can't be converted to a (decimal) integer.
+//
+// Returns:
+//   - `int`: The argument's value as an integer.
+//   - `error`: An [ErrInvalidValue] if the argument is not an integer.
+func (a TArg) IntE() (int, error) {
+	i64, err := strconv.ParseInt(string(a), 10, 0)
+	if nil != err {
+		return int(0), ErrInvalidValue{Arg: string(a), Kind: "integer", Err: err}
 	}
 
-	return int(0)
-} // Int()
+	return int(i64), nil
+} // IntE()
 
 // `String()` returns a stringified version of the argument.
 //
@@ -146,6 +183,15 @@ func (o tOpt) Equal(aOpt tOpt) bool {
 	return o == aOpt
 } // Equal()
 
+// `flag()` returns the option as it is written on the commandline,
+// i.e. with its leading hyphen(s).
+//
+// Returns:
+//   - `string`: The option with its leading hyphen(s).
+func (o tOpt) flag() string {
+	return "-" + string(o)
+} // flag()
+
 // `String()` returns a stringified version of the option.
 //
 // Note: This is mainly for debugging purposes and has no real life use.
@@ -187,92 +233,6 @@ func (oa tOptArg) String() string {
 	return fmt.Sprintf("[%q: %q]", oa.opt, oa.arg)
 } // String()
 
-// --------------------------------------------------------------------
-// tOptArgList Constructor
-
-// `newOptArgList()` creates a new option/argument list based on `aArgList`.
-//
-// The function checks whether at least one argument is passed and then
-// initialises an options/arguments list to store the current commandline
-// options and arguments passed as `aArgList`. If the provided list of
-// commandline options is empty (apart from the name of the running
-// application), the function sets up the standard options "-h" and
-// "--help" with an empty argument (such making them a `flag option`).
-//
-// Note that only the options's name is stored, i.e. the leading hyphen used
-// on the actual commandline is removed. This means that so-called `short
-// options` will be only a single letter and the `long options` will have
-// their first hyphen removed. For example, the `short option` of "-h" will
-// be stored as "h", and the `long option` of "--help" will be stored as
-// "-help".
-//
-// Parameters:
-//   - `aArgList`: A list of commandline options and arguments.
-//
-// Returns:
-//   - `*tArgList`: A pointer to the newly created argument list.
-func newOptArgList(aArgList []string) *tOptArgList {
-	// Previously we used a map to store the key/value pairs. However,
-	// because a map can not keep it's assigned order option/argument
-	// pairs could only accessed in a random order. Hence we switched
-	// to using a slice instead to keep the order of the commandline
-	// options intact.
-	oal := make(tOptArgList, 0, len(aArgList))
-
-	if 1 >= len(aArgList) {
-		var empty TArg
-		// Set up some standard default options:
-		oal = append(oal, tOptArg{tOpt(`h`), empty})
-		oal = append(oal, tOptArg{tOpt(`-help`), empty})
-		// nothing more to do here:
-		return &oal
-	}
-
-	// Get the commandline arguments without the app's path/name
-	// but with an added (empty) argument to allow for a peek ahead
-	// without any range problems when we happen to process the
-	// very last real option or argument.
-	optList := append(aArgList[1:], "")
-
-	// Exclude the peek-ahead dummy added at the end:
-	oLen := len(optList) - 1
-
-	for i := 0; i < oLen; i++ {
-		o := optList[i]
-		if len(o) < 2 {
-			// We expect at least `-o` i.e. two characters.
-			continue
-		}
-		if '-' == o[0] {
-			// It's actually an option (not
-			// an unexpected argument)
-			o = o[1:]
-
-			//
-			//TODO: should we check for `--` as stdOut indicator?
-			//
-
-			p := optList[i+1] // peek ahead
-			// If there's another value that is not
-			// an argument, ignore it here:
-			if (0 < len(p)) && ('-' == p[0]) {
-				// leave it for the next loop step
-				p = ""
-			} else {
-				i++
-			}
-
-			// This might assign an empty argument to the option,
-			// a situation which will be handled by the iterator's
-			// `Next()` method by checking whether an argument is
-			// actually required according to the options pattern.
-			oal = append(oal, tOptArg{tOpt(o), TArg(p)})
-		}
-	}
-
-	return &oal
-} // newOptArgList()
-
 // --------------------------------------------------------------------
 // tOptArgList methods
 
@@ -8,7 +8,6 @@ Copyright © 2024  M.Watermann, 10247 Berlin, Germany
 package getopts
 
 import (
-	"reflect"
 	"testing"
 )
 
@@ -41,6 +40,44 @@ func Test_TArg_Bool(t *testing.T) {
 	}
 } // Test_TArg_Bool()
 
+func Test_TArg_BoolE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    bool
+		wantErr bool
+	}{
+		{"0", TArg(""), false, true},
+		{"1", TArg("+"), false, true},
+		{"2", TArg("1"), true, false},
+		{"3", TArg("no"), false, false},
+		{"4", TArg("Yes"), true, false},
+		{"5", TArg("maybe"), false, true},
+		{"6", TArg("off"), false, false},
+		{"7", TArg("ON"), true, false},
+		{"8", TArg("Nein"), false, false},
+		{"9", TArg("oui"), true, false},
+		{"10", TArg("oops"), false, true},
+		{"11", TArg("tomato"), false, true},
+		{"12", TArg("never"), false, true},
+		{"13", TArg("yikes"), false, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.BoolE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.BoolE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.BoolE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_BoolE()
+
 func Test_TArg_Equal(t *testing.T) {
 	a0, o0 := TArg(""), TArg("")
 	a1, o1 := a0, TArg("1")
@@ -94,6 +131,35 @@ func Test_TArg_Float(t *testing.T) {
 	}
 } // Test_TArg_Float()
 
+func Test_TArg_FloatE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    float64
+		wantErr bool
+	}{
+		{"0", TArg(""), 0.0, true},
+		{"1", TArg("1.23"), 1.23, false},
+		{"2", TArg("-2.34"), -2.34, false},
+		{"3", TArg("n.a."), 0.0, true},
+		{"4", TArg("NaN"), 0.0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.FloatE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.FloatE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.FloatE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_FloatE()
+
 func Test_TArg_Int(t *testing.T) {
 	a0, w0 := TArg(""), 0
 	a1, w1 := TArg("123"), 123
@@ -121,6 +187,36 @@ func Test_TArg_Int(t *testing.T) {
 	}
 } // Test_TArg_Int()
 
+func Test_TArg_IntE(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     TArg
+		want    int
+		wantErr bool
+	}{
+		{"0", TArg(""), 0, true},
+		{"1", TArg("123"), 123, false},
+		{"2", TArg("-234"), -234, false},
+		{"3", TArg("abc"), 0, true},
+		{"4", TArg("0"), 0, false},
+		{"5", TArg("99999999999999999999"), 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.IntE()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TArg.IntE() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TArg.IntE() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_TArg_IntE()
+
 func Test_TArg_String(t *testing.T) {
 	a0, w0 := TArg(""), ""
 	a1, w1 := TArg("12.3"), "12.3"
@@ -202,15 +298,19 @@ func Test_tOpt_String(t *testing.T) {
 } // Test_tOpt_String()
 
 func prepOptArgList() *tOptArgList {
-	// This is the list set up by `init()` for testing purposes
-	args := []string{
-		"testingApplication",
-		`-a`, // Flag option
-		`-i`, // Error: intended with argument => ignored
-		`--infile`, `config.in`,
-		`--help`, // Flag option
+	// The list set up for these commandline arguments:
+	//
+	//	"testingApplication",
+	//	`-a`, // Flag option
+	//	`-i`, // Error: given without an argument
+	//	`--infile`, `config.in`,
+	//	`--help`, // Flag option
+	return &tOptArgList{
+		{tOpt(`a`), TArg(``)},
+		{tOpt(`i`), TArg(``)},
+		{tOpt(`-infile`), TArg(`config.in`)},
+		{tOpt(`-help`), TArg(``)},
 	}
-	return newOptArgList(args)
 } // prepOptArgList()
 
 func Test_tOptArg_Equal(t *testing.T) {
@@ -270,48 +370,6 @@ func Test_tOptArg_String(t *testing.T) {
 	}
 } // Test_tOptArg_String()
 
-func Test_newOptArgList(t *testing.T) {
-	/* This is the list set up by `init()` for testing purposes
-	args = []string{
-		"testingApplication",
-		`-a`, // Flag option
-		`-i`, // Error: intended with argument => ignored
-		`--infile`, `config.in`,
-		`--help`, // Flag option
-	}
-	*/
-	var empty TArg
-	a1 := []string{
-		"testingApplication",
-		`-a`, // Flag option
-		`-i`, // Error: intended with argument => ignored
-		`--infile`, `config.in`,
-		`--help`, // Flag option
-	}
-	w1 := make(tOptArgList, 0, 4)
-	w1 = append(w1, tOptArg{tOpt(`a`), empty})
-	w1 = append(w1, tOptArg{tOpt(`i`), empty})
-	w1 = append(w1, tOptArg{tOpt(`-infile`), TArg(`config.in`)})
-	w1 = append(w1, tOptArg{tOpt(`-help`), empty})
-
-	tests := []struct {
-		name string
-		args []string
-		want *tOptArgList
-	}{
-		{"1", a1, &w1},
-		// TODO: Add test cases.
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := newOptArgList(tt.args); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("%q: newOptArgList() =\n%v\n want \n%v",
-					tt.name, got, tt.want)
-			}
-		})
-	}
-} // Test_newOptArgList()
-
 func Test_tOptArgList_Equal(t *testing.T) {
 	oal1 := tOptArgList{}
 	woa1 := tOptArgList{}
@@ -0,0 +1,324 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TRule` is a check of the relations between several options
+	// performed by [TParser.Validate].
+	//
+	// The rule returns `nil` if the parser's options fulfill it, or
+	// an error describing the problem otherwise.
+	TRule func(aParser *TParser) error
+
+	// `TValidator` is a check of a single option's argument.
+	//
+	// The validator returns `nil` if the argument is acceptable, or an
+	// error describing the problem otherwise. The parser reports that
+	// error wrapped by an [ErrInvalidArgument].
+	TValidator func(aArg TArg) error
+)
+
+// --------------------------------------------------------------------
+// TRule constructors
+
+// `Exclusive()` returns a rule allowing at most one of the given
+// options, e.g. `Exclusive("q", "v")` for `-q` (quiet) and `-v`
+// (verbose).
+//
+// Only options given on the commandline, by the environment, or by a
+// configuration file are considered while default values are not.
+//
+// Parameters:
+//   - `aOpts`: The names (or aliases) of the mutually exclusive options.
+//
+// Returns:
+//   - `TRule`: The rule reporting an [ErrExclusiveOptions] error.
+func Exclusive(aOpts ...string) TRule {
+	return func(aParser *TParser) error {
+		var given []string
+		for _, opt := range aOpts {
+			if aParser.isSet(opt) {
+				given = append(given, string(aParser.iter.expected.canonical(tOpt(opt))))
+			}
+		}
+		if 1 < len(given) {
+			return ErrExclusiveOptions{Opts: given}
+		}
+
+		return nil
+	}
+} // Exclusive()
+
+// `OneOf()` returns a rule requiring at least one of the given options,
+// e.g. `OneOf("-file", "-url")`.
+//
+// Parameters:
+//   - `aOpts`: The names (or aliases) of the options.
+//
+// Returns:
+//   - `TRule`: The rule reporting an [ErrOneOfOptions] error.
+func OneOf(aOpts ...string) TRule {
+	return func(aParser *TParser) error {
+		opts := make([]string, 0, len(aOpts))
+		for _, opt := range aOpts {
+			if _, ok := aParser.Lookup(opt); ok {
+				return nil
+			}
+			opts = append(opts, string(aParser.iter.expected.canonical(tOpt(opt))))
+		}
+
+		return ErrOneOfOptions{Opts: opts}
+	}
+} // OneOf()
+
+// `Requires()` returns a rule requiring the options `aDeps` if the
+// option `aOpt` is given, e.g. `Requires("-key", "-cert")`.
+//
+// The option `aOpt` is only considered if it's given on the commandline,
+// by the environment, or by a configuration file (i.e. a default value
+// doesn't count) while its dependencies may have a default value.
+//
+// Parameters:
+//   - `aOpt`: The name (or an alias) of the dependent option.
+//   - `aDeps`: The names (or aliases) of the options needed by `aOpt`.
+//
+// Returns:
+//   - `TRule`: The rule reporting an [ErrDependentOption] error
+//     for each missing dependency.
+func Requires(aOpt string, aDeps ...string) TRule {
+	return func(aParser *TParser) error {
+		if !aParser.isSet(aOpt) {
+			return nil
+		}
+
+		eo := aParser.iter.expected
+		var errs []error
+		for _, dep := range aDeps {
+			if _, ok := aParser.Lookup(dep); !ok {
+				errs = append(errs, ErrDependentOption{
+					Opt:      string(eo.canonical(tOpt(aOpt))),
+					Requires: string(eo.canonical(tOpt(dep))),
+				})
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+} // Requires()
+
+// --------------------------------------------------------------------
+// TValidator constructors
+
+// `Choices()` returns a validator accepting only the given arguments,
+// e.g. `Choices("json", "yaml", "text")`.
+//
+// Parameters:
+//   - `aChoices`: The allowed arguments.
+//
+// Returns:
+//   - `TValidator`: The validator.
+func Choices(aChoices ...string) TValidator {
+	return func(aArg TArg) error {
+		for _, choice := range aChoices {
+			if string(aArg) == choice {
+				return nil
+			}
+		}
+		quoted := make([]string, 0, len(aChoices))
+		for _, choice := range aChoices {
+			quoted = append(quoted, strconv.Quote(choice))
+		}
+
+		return fmt.Errorf("must be one of %s", strings.Join(quoted, ", "))
+	}
+} // Choices()
+
+// `Matches()` returns a validator accepting only arguments matching
+// the given regular expression, e.g. `Matches("^[a-z][a-z0-9]*$")`.
+//
+// NOTE: Like `regexp.MustCompile()` this function panics if the
+// regular expression can't be compiled.
+//
+// Parameters:
+//   - `aExpr`: The regular expression to match.
+//
+// Returns:
+//   - `TValidator`: The validator.
+func Matches(aExpr string) TValidator {
+	re := regexp.MustCompile(aExpr)
+
+	return func(aArg TArg) error {
+		if re.MatchString(string(aArg)) {
+			return nil
+		}
+
+		return fmt.Errorf("must match %q", aExpr)
+	}
+} // Matches()
+
+// `Range()` returns a validator accepting only numeric arguments
+// within the given (inclusive) range, e.g. `Range(1, 65535)`.
+//
+// Parameters:
+//   - `aMin`: The smallest acceptable value.
+//   - `aMax`: The largest acceptable value.
+//
+// Returns:
+//   - `TValidator`: The validator.
+func Range(aMin, aMax float64) TValidator {
+	return func(aArg TArg) error {
+		f64, err := aArg.FloatE()
+		if nil != err {
+			return errors.New("not a number")
+		}
+		if (aMin > f64) || (aMax < f64) {
+			return fmt.Errorf("must be between %s and %s",
+				strconv.FormatFloat(aMin, 'g', -1, 64),
+				strconv.FormatFloat(aMax, 'g', -1, 64))
+		}
+
+		return nil
+	}
+} // Range()
+
+// --------------------------------------------------------------------
+// tOptSpec methods
+
+// `validate()` checks the given argument against the option's choices
+// and validators.
+//
+// Flag options and empty (optional) arguments are not checked.
+//
+// Parameters:
+//   - `aArg`: The option's argument to check.
+//
+// Returns:
+//   - `error`: `nil` if valid, an [ErrInvalidArgument] otherwise.
+func (sp *tOptSpec) validate(aArg TArg) error {
+	if (nil == sp) || (argNone == sp.argMode) || ("" == aArg) {
+		return nil
+	}
+
+	validators := sp.validators
+	if 0 < len(sp.choices) {
+		validators = append([]TValidator{Choices(sp.choices...)}, validators...)
+	}
+	for _, validator := range validators {
+		if nil == validator {
+			continue
+		}
+		if err := validator(aArg); nil != err {
+			return ErrInvalidArgument{Opt: string(sp.name), Arg: string(aArg), Err: err}
+		}
+	}
+
+	return nil
+} // validate()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `AddValidator()` attaches further checks to the arguments of the
+// given option.
+//
+// This is meant for options declared by a pattern; with [NewParserFor]
+// the validators can be given by the option's declaration as well. The
+// options are checked again, so violations are reported by the parser's
+// errors (see [TParser.Errors]).
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases).
+//   - `aValidators`: The checks to add (see [Choices], [Matches], and [Range]).
+//
+// Returns:
+//   - `*TParser`: The parser instance with the updated options.
+func (p *TParser) AddValidator(aOpt string, aValidators ...TValidator) *TParser {
+	spec, ok := p.iter.expected.specs[tOpt(aOpt)]
+	if !ok {
+		return p
+	}
+	spec.validators = append(spec.validators, aValidators...)
+
+	return p.setExpected(p.iter.expected)
+} // AddValidator()
+
+// `isSet()` returns whether the given option has a value other than
+// its default value.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases).
+//
+// Returns:
+//   - `bool`: Indicator for whether the option is set.
+func (p *TParser) isSet(aOpt string) bool {
+	if _, ok := p.Lookup(aOpt); !ok {
+		return false
+	}
+
+	return SourceDefault != p.Source(aOpt)
+} // isSet()
+
+// `Validate()` checks the parser's options all at once.
+//
+// The result includes the problems found while processing the
+// commandline (see [TParser.Errors]), an [ErrRequiredOption] error for
+// each option declared as required (e.g. by the pattern `o:!`) that has
+// no value from any source, and the errors reported by the given rules
+// (see [Exclusive], [Requires], and [OneOf]), e.g.:
+//
+//	err := p.Validate(
+//		getopts.Exclusive("q", "v"),
+//		getopts.Requires("-key", "-cert"),
+//		getopts.OneOf("-file", "-url"))
+//
+// The single problems can be examined by using `errors.As()`.
+//
+// Parameters:
+//   - `aRules`: The rules to check.
+//
+// Returns:
+//   - `error`: `nil` if there are no problems, or all errors joined.
+func (p *TParser) Validate(aRules ...TRule) error {
+	errs := p.Errors()
+
+	for _, spec := range p.iter.expected.order {
+		if !spec.required {
+			continue
+		}
+		if _, ok := p.Lookup(string(spec.name)); !ok {
+			errs = append(errs, ErrRequiredOption{Opt: string(spec.name)})
+		}
+	}
+
+	for _, rule := range aRules {
+		if nil == rule {
+			continue
+		}
+		err := rule(p)
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			// Keep the list of problems flat:
+			errs = append(errs, joined.Unwrap()...)
+		} else if nil != err {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+} // Validate()
+
+/* _EoF_ */
@@ -0,0 +1,163 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTParser_Validate(t *testing.T) {
+	pattern := "o,-output:!|q,-quiet|v,-verbose|-key:|-cert:|-ca:=ca.pem|-file:|-url:|-mode:=fast"
+	rules := []TRule{
+		Exclusive("q", "-verbose", "-mode"),
+		Requires("-key", "-cert", "-ca"),
+		OneOf("-file", "-url"),
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []error
+	}{
+		{"1", []string{`app`, `-o`, `x`, `--file`, `f`}, nil},
+		{"2", []string{`app`, `--file`, `f`}, []error{ErrRequiredOption{Opt: "o"}}},
+		{"3", []string{`app`, `-o`, `x`, `--url`, `u`, `-qv`},
+			[]error{ErrExclusiveOptions{Opts: []string{"q", "v"}}}},
+		{"4", []string{`app`, `-o`, `x`, `--url`, `u`, `--key`, `k`},
+			[]error{ErrDependentOption{Opt: "-key", Requires: "-cert"}}},
+		{"5", []string{`app`, `-o`, `x`, `--url`, `u`, `--key`, `k`, `--cert`, `c`}, nil},
+		{"6", []string{`app`, `-o`, `x`}, []error{ErrOneOfOptions{Opts: []string{"-file", "-url"}}}},
+		{"7", []string{`app`, `-x`},
+			[]error{ErrUnknownOption{Opt: "x"}, ErrRequiredOption{Opt: "o"}, ErrOneOfOptions{Opts: []string{"-file", "-url"}}}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewParser(tt.args, pattern).Validate(rules...)
+			var got []error
+			if nil != err {
+				got = err.(interface{ Unwrap() []error }).Unwrap()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TParser.Validate() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_Validate()
+
+func TestTParser_Validate_option(t *testing.T) {
+	p := NewParserFor([]string{`app`}, []TOption{
+		{Pattern: "o,-output:", Required: true},
+		{Pattern: "p,-port:", Required: true, Default: "80"},
+	})
+	var re ErrRequiredOption
+	if err := p.Validate(); !errors.As(err, &re) || ("o" != re.Opt) {
+		t.Errorf("TParser.Validate() = %v, want %v", err, ErrRequiredOption{Opt: "o"})
+	}
+} // TestTParser_Validate_option()
+
+func TestTValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator TValidator
+		arg       TArg
+		want      string
+	}{
+		{"1", Choices("json", "yaml"), "json", ""},
+		{"2", Choices("json", "yaml"), "xml", `must be one of "json", "yaml"`},
+		{"3", Range(1, 65535), "8080", ""},
+		{"4", Range(1, 65535), "0", `must be between 1 and 65535`},
+		{"5", Range(0, 0.5), "abc", `not a number`},
+		{"6", Matches(`^[a-z]+$`), "abc", ""},
+		{"7", Matches(`^[a-z]+$`), "ABC", `must match "^[a-z]+$"`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if err := tt.validator(tt.arg); nil != err {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("%q: TValidator() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTValidator()
+
+func TestTParser_AddValidator(t *testing.T) {
+	even := func(aArg TArg) error {
+		if 0 != aArg.Int()%2 {
+			return errors.New("must be even")
+		}
+		return nil
+	}
+	options := []TOption{
+		{Pattern: "f,-format:=text", Choices: []string{"json", "yaml", "text"}},
+		{Pattern: "p,-port:", Validators: []TValidator{Range(1, 65535), even}},
+		{Pattern: "v,-verbose"},
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []error
+	}{
+		{"1", []string{`app`, `-f`, `json`, `-p`, `80`, `-v`}, nil},
+		{"2", []string{`app`}, nil},
+		{"3", []string{`app`, `--format=xml`},
+			[]error{ErrInvalidArgument{Opt: "f", Arg: "xml"}}},
+		{"4", []string{`app`, `-p`, `0`},
+			[]error{ErrInvalidArgument{Opt: "p", Arg: "0"}}},
+		{"5", []string{`app`, `-p`, `81`, `-f`, `csv`},
+			[]error{ErrInvalidArgument{Opt: "p", Arg: "81"}, ErrInvalidArgument{Opt: "f", Arg: "csv"}}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewParserFor(tt.args, options).Errors()
+			if len(got) != len(tt.want) {
+				t.Errorf("%q: TParser.Errors() = %v, want %v",
+					tt.name, got, tt.want)
+				return
+			}
+			for idx, err := range got {
+				var ia ErrInvalidArgument
+				want := tt.want[idx].(ErrInvalidArgument)
+				if !errors.As(err, &ia) || (ia.Opt != want.Opt) || (ia.Arg != want.Arg) {
+					t.Errorf("%q: TParser.Errors() = %v, want %v",
+						tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+
+	p := NewParser([]string{`app`, `-n`, `Bob`}, "n:").AddValidator("n", Matches(`^[a-z]+$`))
+	want := `getopts: invalid argument "Bob" of option "-n": must match "^[a-z]+$"`
+	if err := p.Err(); (nil == err) || (want != err.Error()) {
+		t.Errorf("TParser.Err() = %v, want %q", err, want)
+	}
+
+	// Parsers sharing a declaration don't share added validators:
+	validators := make([]TValidator, 1, 2)
+	validators[0] = Range(1, 65535)
+	decl := []TOption{{Pattern: "p:", Validators: validators}}
+	p1 := NewParserFor([]string{`app`, `-p`, `80`}, decl).AddValidator("p", Range(1, 10))
+	NewParserFor([]string{`app`, `-p`, `80`}, decl).AddValidator("p", Range(1, 100))
+	if err := p1.AddValidator("p").Err(); nil == err {
+		t.Errorf("TParser.AddValidator() Err() = %v, want %T", err, ErrInvalidArgument{})
+	}
+} // TestTParser_AddValidator()
+
+/* _EoF_ */
@@ -0,0 +1,340 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The hidden option requesting a completion script
+	// (i.e. `--generate-completion <shell>`).
+	completionOpt = tOpt(`-generate-completion`)
+)
+
+// `CompletionWriter` is the destination of the completion script
+// requested by the hidden option `--generate-completion <shell>`.
+//
+// If it's `nil` the script is written to `os.Stdout`.
+var CompletionWriter io.Writer
+
+// --------------------------------------------------------------------
+// tExpectedOpts methods
+
+// `addCompletion()` registers the hidden completion option (unless
+// it's declared explicitly).
+//
+// The option is not added to the list of declared options, so it's
+// neither shown in the help text nor completed itself.
+func (eo *tExpectedOpts) addCompletion() {
+	if _, ok := eo.specs[completionOpt]; ok {
+		return
+	}
+	eo.specs[completionOpt] = &tOptSpec{
+		name:    completionOpt,
+		argMode: argRequired,
+	}
+} // addCompletion()
+
+// --------------------------------------------------------------------
+// helper functions
+
+// `compFuncName()` returns a shell function's name for the given
+// application.
+//
+// Parameters:
+//   - `aName`: The application's name.
+//
+// Returns:
+//   - `string`: The function's name, e.g. `_my_prog` for `my-prog`.
+func compFuncName(aName string) string {
+	return "_" + strings.Map(func(r rune) rune {
+		if (('a' <= r) && ('z' >= r)) || (('A' <= r) && ('Z' >= r)) || (('0' <= r) && ('9' >= r)) {
+			return r
+		}
+		return '_'
+	}, aName)
+} // compFuncName()
+
+// `compQuote()` quotes the given text for use in a shell script.
+//
+// Parameters:
+//   - `aText`: The text to quote.
+//
+// Returns:
+//   - `string`: The text in single quotes.
+func compQuote(aText string) string {
+	return `'` + strings.ReplaceAll(aText, `'`, `'\''`) + `'`
+} // compQuote()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `bashCompletion()` returns the completion script for `bash`.
+//
+// Returns:
+//   - `string`: The completion script.
+func (p *TParser) bashCompletion() string {
+	var (
+		sb    strings.Builder
+		flags []string
+	)
+	name := p.name()
+	fn := compFuncName(name)
+
+	fmt.Fprintf(&sb, "# bash completion for %s\n", name)
+	fmt.Fprintf(&sb, "%s() {\n", fn)
+	sb.WriteString("\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	sb.WriteString("\tlocal prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+	sb.WriteString("\tcase \"${prev}\" in\n")
+	for _, sp := range p.iter.expected.order {
+		names := make([]string, 0, len(sp.aliases)+1)
+		for _, n := range sp.names() {
+			names = append(names, n.flag())
+		}
+		flags = append(flags, names...)
+		if argRequired != sp.argMode {
+			continue
+		}
+		fmt.Fprintf(&sb, "\t%s)\n", strings.Join(names, "|"))
+		if nil != sp.completer {
+			fmt.Fprintf(&sb, "\t\tCOMPREPLY=($(compgen -W \"$(\"${COMP_WORDS[0]}\" %s \"${COMP_WORDS[@]:1:COMP_CWORD}\" 2>/dev/null)\" -- \"${cur}\"))\n",
+				completeCmd)
+		} else if 0 < len(sp.choices) {
+			fmt.Fprintf(&sb, "\t\tCOMPREPLY=($(compgen -W %s -- \"${cur}\"))\n",
+				compQuote(strings.Join(sp.choices, " ")))
+		} else {
+			sb.WriteString("\t\tCOMPREPLY=($(compgen -f -- \"${cur}\"))\n")
+		}
+		sb.WriteString("\t\treturn 0\n\t\t;;\n")
+	}
+	sb.WriteString("\tesac\n\n")
+
+	sb.WriteString("\tif [[ \"${cur}\" == -* ]]; then\n")
+	fmt.Fprintf(&sb, "\t\tCOMPREPLY=($(compgen -W %s -- \"${cur}\"))\n",
+		compQuote(strings.Join(flags, " ")))
+	sb.WriteString("\t\treturn 0\n\tfi\n")
+
+	if cmds := p.commandNames(); 0 < len(cmds) {
+		fmt.Fprintf(&sb, "\tCOMPREPLY=($(compgen -W %s -- \"${cur}\"))\n",
+			compQuote(strings.Join(cmds, " ")))
+	} else {
+		sb.WriteString("\tCOMPREPLY=($(compgen -f -- \"${cur}\"))\n")
+	}
+	fmt.Fprintf(&sb, "}\n\ncomplete -F %s %s\n", fn, name)
+
+	return sb.String()
+} // bashCompletion()
+
+// `commandNames()` returns the names of the parser's subcommands.
+//
+// Returns:
+//   - `[]string`: The subcommands' names (empty if there are none).
+func (p *TParser) commandNames() []string {
+	var result []string
+	for _, c := range p.commands {
+		if nil != c {
+			result = append(result, c.Name)
+		}
+	}
+
+	return result
+} // commandNames()
+
+// `fishCompletion()` returns the completion script for `fish`.
+//
+// Returns:
+//   - `string`: The completion script.
+func (p *TParser) fishCompletion() string {
+	var sb strings.Builder
+	name := p.name()
+
+	fmt.Fprintf(&sb, "# fish completion for %s\n", name)
+	for _, c := range p.commands {
+		if nil == c {
+			continue
+		}
+		fmt.Fprintf(&sb, "complete -c %s -n __fish_use_subcommand -f -a %s -d %s\n",
+			name, compQuote(c.Name), compQuote(c.Description))
+	}
+
+	for _, sp := range p.iter.expected.order {
+		fmt.Fprintf(&sb, "complete -c %s", name)
+		for _, n := range sp.names() {
+			switch {
+			case strings.HasPrefix(string(n), `-`):
+				fmt.Fprintf(&sb, " -l %s", compQuote(string(n[1:])))
+			case 1 == len([]rune(string(n))):
+				fmt.Fprintf(&sb, " -s %s", compQuote(string(n)))
+			default:
+				fmt.Fprintf(&sb, " -o %s", compQuote(string(n)))
+			}
+		}
+		switch {
+		case nil != sp.completer:
+			if argRequired == sp.argMode {
+				sb.WriteString(" -x")
+			}
+			fmt.Fprintf(&sb, " -a %s", compQuote(fmt.Sprintf(
+				"(%s %s (commandline -opc)[2..-1] (commandline -ct))", name, completeCmd)))
+		case 0 < len(sp.choices):
+			if argRequired == sp.argMode {
+				sb.WriteString(" -x")
+			}
+			fmt.Fprintf(&sb, " -a %s", compQuote(strings.Join(sp.choices, " ")))
+		case argRequired == sp.argMode:
+			sb.WriteString(" -r -F")
+		}
+		if desc := sp.usage(p.envPrefix); "" != desc {
+			fmt.Fprintf(&sb, " -d %s", compQuote(desc))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+} // fishCompletion()
+
+// `zshCompletion()` returns the completion script for `zsh`.
+//
+// Returns:
+//   - `string`: The completion script.
+func (p *TParser) zshCompletion() string {
+	var sb strings.Builder
+	name := p.name()
+	fn := compFuncName(name)
+	escape := strings.NewReplacer(`[`, `\[`, `]`, `\]`, `:`, `\:`)
+
+	fmt.Fprintf(&sb, "#compdef %s\n\n", name)
+	fmt.Fprintf(&sb, "%s() {\n\t_arguments -s -S", fn)
+	for _, sp := range p.iter.expected.order {
+		flags := make([]string, 0, len(sp.aliases)+1)
+		for _, n := range sp.names() {
+			flags = append(flags, n.flag())
+		}
+		exclude := "(" + strings.Join(flags, " ") + ")"
+		if sp.repeat {
+			exclude = "*"
+		}
+
+		action := "_files"
+		if nil != sp.completer {
+			action = `{local -a c; c=(${(f)"$(${words[1]} ` + completeCmd +
+				` ${(@)words[2,CURRENT]} 2>/dev/null)"}); compadd -a c}`
+		} else if 0 < len(sp.choices) {
+			action = "(" + strings.Join(sp.choices, " ") + ")"
+		}
+		placeholder := sp.placeholder
+		if "" == placeholder {
+			placeholder = "ARG"
+		}
+		desc := ""
+		if usage := sp.usage(p.envPrefix); "" != usage {
+			desc = "[" + escape.Replace(usage) + "]"
+		}
+
+		for _, flag := range flags {
+			spec := exclude + flag
+			switch sp.argMode {
+			case argRequired:
+				if strings.HasPrefix(flag, `--`) {
+					spec += "="
+				} else {
+					spec += "+"
+				}
+				spec += desc + ":" + escape.Replace(placeholder) + ":" + action
+			case argOptional:
+				if strings.HasPrefix(flag, `--`) {
+					spec += "=-"
+				} else {
+					spec += "-"
+				}
+				spec += desc + "::" + escape.Replace(placeholder) + ":" + action
+			default:
+				spec += desc
+			}
+			fmt.Fprintf(&sb, " \\\n\t\t%s", compQuote(spec))
+		}
+	}
+	if cmds := p.commandNames(); 0 < len(cmds) {
+		fmt.Fprintf(&sb, " \\\n\t\t%s", compQuote("1:command:("+strings.Join(cmds, " ")+")"))
+	}
+	fmt.Fprintf(&sb, " \\\n\t\t%s\n}\n\n", compQuote("*:file:_files"))
+
+	fmt.Fprintf(&sb, "if [ \"$funcstack[1]\" = \"%s\" ]; then\n", fn)
+	fmt.Fprintf(&sb, "\t%s \"$@\"\nelse\n\tcompdef %s %s\nfi\n", fn, fn, name)
+
+	return sb.String()
+} // zshCompletion()
+
+// `Completion()` returns a script completing the application's options
+// in the given shell.
+//
+// The script completes the short and long names of all declared options.
+// The arguments of options requiring one are completed by calling the
+// application with the hidden operand `__complete` if the option has a
+// completer (see [TCompleter]), by their declared choices (see [TOption]),
+// or else by file names. For a [TCommand] with
+// subcommands the names of those are completed as well.
+//
+// The script can be installed e.g. by
+//
+//	myprog --generate-completion bash > /etc/bash_completion.d/myprog
+//
+// Parameters:
+//   - `aShell`: The shell's name, i.e. `bash`, `zsh`, or `fish`.
+//
+// Returns:
+//   - `string`: The completion script.
+//   - `error`: An error if the shell is not supported.
+func (p *TParser) Completion(aShell string) (string, error) {
+	switch strings.ToLower(aShell) {
+	case "bash":
+		return p.bashCompletion(), nil
+	case "fish":
+		return p.fishCompletion(), nil
+	case "zsh":
+		return p.zshCompletion(), nil
+	}
+
+	return "", fmt.Errorf("getopts: unsupported shell %q (use bash, fish, or zsh)", aShell)
+} // Completion()
+
+// `showCompletion()` handles the hidden option `--generate-completion`
+// by writing the requested completion script to [CompletionWriter] and
+// calling [ExitFunc] with the exit code `0` (if it's not `nil`).
+//
+// Parameters:
+//   - `aShell`: The shell's name.
+//
+// Returns:
+//   - `error`: A possible error generating or writing the script.
+func (p *TParser) showCompletion(aShell string) error {
+	script, err := p.Completion(aShell)
+	if nil != err {
+		return err
+	}
+	w := CompletionWriter
+	if nil == w {
+		w = os.Stdout
+	}
+	if _, err = io.WriteString(w, script); nil != err {
+		return err
+	}
+	if nil != ExitFunc {
+		ExitFunc(0)
+	}
+
+	return nil
+} // showCompletion()
+
+/* _EoF_ */
@@ -0,0 +1,130 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepCompletion() *TParser {
+	return NewParserFor([]string{`/usr/bin/my-prog`}, []TOption{
+		{Pattern: "o,-output:", Arg: "FILE", Description: "write to FILE"},
+		{Pattern: "f,-format:", Choices: []string{"json", "yaml"}},
+		{Pattern: "v,-verbose"},
+	})
+} // prepCompletion()
+
+func Test_compFuncName(t *testing.T) {
+	tests := []struct {
+		name string
+		app  string
+		want string
+	}{
+		{"1", "myprog", "_myprog"},
+		{"2", "my-prog", "_my_prog"},
+		{"3", "my.prog2", "_my_prog2"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compFuncName(tt.app); got != tt.want {
+				t.Errorf("%q: compFuncName() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_compFuncName()
+
+func TestTParser_Completion(t *testing.T) {
+	tests := []struct {
+		name    string
+		shell   string
+		want    []string
+		wantErr bool
+	}{
+		{"1", "bash", []string{
+			"\t-o|--output)\n\t\tCOMPREPLY=($(compgen -f -- \"${cur}\"))\n",
+			"\t-f|--format)\n\t\tCOMPREPLY=($(compgen -W 'json yaml' -- \"${cur}\"))\n",
+			"compgen -W '-o --output -f --format -v --verbose' -- ",
+			"complete -F _my_prog my-prog\n",
+		}, false},
+		{"2", "zsh", []string{
+			"#compdef my-prog\n",
+			`'(-o --output)--output=[write to FILE]:FILE:_files'`,
+			`'(-f --format)-f+[(one of\: json, yaml)]:ARG:(json yaml)'`,
+			`'(-v --verbose)-v' \`,
+			"compdef _my_prog my-prog\n",
+		}, false},
+		{"3", "fish", []string{
+			"complete -c my-prog -s 'o' -l 'output' -r -F -d 'write to FILE'\n",
+			"complete -c my-prog -s 'f' -l 'format' -x -a 'json yaml' -d '(one of: json, yaml)'\n",
+			"complete -c my-prog -s 'v' -l 'verbose'\n",
+		}, false},
+		{"4", "tcsh", nil, true},
+		// TODO: Add test cases.
+	}
+	p := prepCompletion()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Completion(tt.shell)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: TParser.Completion() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("%q: TParser.Completion() =\n%s\n missing %q",
+						tt.name, got, want)
+				}
+			}
+			if strings.Contains(got, "generate-completion") {
+				t.Errorf("%q: TParser.Completion() contains the hidden option", tt.name)
+			}
+		})
+	}
+} // TestTParser_Completion()
+
+func TestTParser_Get_completion(t *testing.T) {
+	var buf bytes.Buffer
+	exitCode := -1
+	oldExit, oldWriter := ExitFunc, CompletionWriter
+	ExitFunc = func(aCode int) { exitCode = aCode }
+	CompletionWriter = &buf
+	defer func() {
+		ExitFunc, CompletionWriter = oldExit, oldWriter
+	}()
+
+	p := NewParser([]string{`myprog`, `-v`, `--generate-completion`, `fish`, `-q`}, "v|q")
+	var opts []string
+	for {
+		opt, _, more := p.Get()
+		opts = append(opts, opt)
+		if !more {
+			break
+		}
+	}
+	if want := []string{"v", "?"}; strings.Join(opts, " ") != strings.Join(want, " ") {
+		t.Errorf("TParser.Get() = %q, want %q", opts, want)
+	}
+	if 0 != exitCode {
+		t.Errorf("ExitFunc() code = %d, want %d", exitCode, 0)
+	}
+	if want, _ := p.Completion("fish"); buf.String() != want {
+		t.Errorf("CompletionWriter =\n%s\n want \n%s", buf.String(), want)
+	}
+	if err := p.Err(); nil != err {
+		t.Errorf("TParser.Err() = %v, want nil", err)
+	}
+} // TestTParser_Get_completion()
+
+/* _EoF_ */
@@ -0,0 +1,323 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TCompleter` returns the completion candidates for an option's
+	// argument, e.g. host or project names only known at runtime.
+	//
+	// The given parser holds the options of the partial commandline
+	// (i.e. all words before the one to complete), so the candidates
+	// may depend on other options already given. The returned list
+	// doesn't need to be filtered by `aPrefix`; candidates not starting
+	// with it are dropped anyway.
+	TCompleter func(aParser *TParser, aPrefix string) []string
+)
+
+const (
+	// The hidden operand requesting the dynamic completion
+	// (i.e. `myprog __complete <word>...`).
+	completeCmd = `__complete`
+)
+
+// --------------------------------------------------------------------
+// helper functions
+
+// `filterPrefix()` returns all candidates starting with the given
+// prefix, each preceded by `aLead`.
+//
+// Parameters:
+//   - `aList`: The candidates to filter.
+//   - `aPrefix`: The partial word to complete.
+//   - `aLead`: The text to put in front of each candidate.
+//
+// Returns:
+//   - `[]string`: The matching candidates.
+func filterPrefix(aList []string, aPrefix, aLead string) []string {
+	var result []string
+	for _, item := range aList {
+		if strings.HasPrefix(item, aPrefix) {
+			result = append(result, aLead+item)
+		}
+	}
+
+	return result
+} // filterPrefix()
+
+// `showCandidates()` writes the completion candidates for the given
+// words to [CompletionWriter] – one per line – and calls [ExitFunc]
+// with the exit code `0` (if it's not `nil`).
+//
+// Parameters:
+//   - `aCandidates`: The candidates to write.
+//
+// Returns:
+//   - `error`: A possible error writing the candidates.
+func showCandidates(aCandidates []string) error {
+	w := CompletionWriter
+	if nil == w {
+		w = os.Stdout
+	}
+	for _, c := range aCandidates {
+		if _, err := io.WriteString(w, c+"\n"); nil != err {
+			return err
+		}
+	}
+	if nil != ExitFunc {
+		ExitFunc(0)
+	}
+
+	return nil
+} // showCandidates()
+
+// --------------------------------------------------------------------
+// tOptSpec methods
+
+// `complete()` returns the candidates for the option's argument.
+//
+// Parameters:
+//   - `aParser`: The parser of the partial commandline.
+//   - `aPrefix`: The partial argument to complete.
+//   - `aLead`: The text to put in front of each candidate.
+//
+// Returns:
+//   - `[]string`: The matching candidates.
+func (sp *tOptSpec) complete(aParser *TParser, aPrefix, aLead string) []string {
+	list := sp.choices
+	if nil != sp.completer {
+		list = sp.completer(aParser, aPrefix)
+	}
+
+	return filterPrefix(list, aPrefix, aLead)
+} // complete()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `candidates()` returns the completion candidates for the last one
+// of the given words.
+//
+// The words before the last one are interpreted the same way as the
+// commandline (see [tExpectedOpts.optArgList]). If the last of them is
+// an option still waiting for its argument, the option's completer
+// (or its choices) provide the candidates. A word starting with a
+// hyphen is completed by the names of the declared options (or by the
+// argument of a `--name=` option). Any other word is completed by the
+// names of the subcommands (if any).
+//
+// Parameters:
+//   - `aWords`: The commandline words following the application's name
+//     with the partial word to complete as the last one.
+//
+// Returns:
+//   - `[]string`: The matching candidates.
+func (p *TParser) candidates(aWords []string) []string {
+	if 0 == len(aWords) {
+		aWords = []string{""}
+	}
+	last := len(aWords) - 1
+	cur := aWords[last]
+	eo := p.iter.expected
+
+	// The parser of the words already given:
+	args := append([]string{p.name()}, aWords[:last]...)
+	ctx := &TParser{
+		args:      args,
+		iter:      newIterator(&tOptArgList{}),
+		parent:    p.parent,
+		commands:  p.commands,
+		envPrefix: p.envPrefix,
+		config:    p.config,
+	}
+	ctx.setExpected(eo)
+
+	if spec := eo.pending(args); nil != spec {
+		return spec.complete(ctx, cur, "")
+	}
+
+	if !strings.HasPrefix(cur, `-`) {
+		return filterPrefix(p.commandNames(), cur, "")
+	}
+	if pos := strings.IndexByte(cur, '='); strings.HasPrefix(cur, `--`) && (0 < pos) {
+		if spec, ok := eo.specs[tOpt(cur[1:pos])]; ok && (argNone != spec.argMode) {
+			return spec.complete(ctx, cur[pos+1:], cur[:pos+1])
+		}
+		return nil
+	}
+
+	var flags []string
+	for _, sp := range eo.order {
+		for _, n := range sp.names() {
+			flags = append(flags, n.flag())
+		}
+	}
+
+	return filterPrefix(flags, cur, "")
+} // candidates()
+
+// `completionRequested()` returns whether the first word of the
+// commandline is `__complete`.
+//
+// Returns:
+//   - `bool`: Indicator for whether the completion was requested.
+func (p *TParser) completionRequested() bool {
+	return (nil == p.parent) && (1 < len(p.args)) && (completeCmd == p.args[1])
+} // completionRequested()
+
+// `isCompleting()` returns whether the dynamic completion was requested,
+// i.e. whether the first word of the commandline is `__complete` and
+// an option declares a [TCompleter].
+//
+// Only the completion scripts of such applications use `__complete`;
+// for all others it's an ordinary operand.
+//
+// Returns:
+//   - `bool`: Indicator for whether to complete the commandline.
+func (p *TParser) isCompleting() bool {
+	return p.completionRequested() && p.iter.expected.hasCompleter()
+} // isCompleting()
+
+// `SetCompleter()` sets up the function providing the completion
+// candidates for the argument of the given option.
+//
+// This is meant for options declared by a pattern; with [NewParserFor]
+// the completer can be given by the option's declaration as well. The
+// options are processed again (like with [TParser.AddValidator]), so
+// it's meant to be called before the iteration by [TParser.Get].
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases).
+//   - `aCompleter`: The function returning the candidates.
+//
+// Returns:
+//   - `*TParser`: The parser instance.
+func (p *TParser) SetCompleter(aOpt string, aCompleter TCompleter) *TParser {
+	spec, ok := p.iter.expected.specs[tOpt(aOpt)]
+	if !ok {
+		return p
+	}
+	spec.completer = aCompleter
+
+	// A completer decides whether `__complete` is an operand:
+	return p.setExpected(p.iter.expected)
+} // SetCompleter()
+
+// --------------------------------------------------------------------
+// tExpectedOpts methods
+
+// `hasCompleter()` returns whether any option declares a [TCompleter].
+//
+// Returns:
+//   - `bool`: Indicator for whether there's a completer.
+func (eo *tExpectedOpts) hasCompleter() bool {
+	for _, sp := range eo.order {
+		if nil != sp.completer {
+			return true
+		}
+	}
+
+	return false
+} // hasCompleter()
+
+// `pending()` returns the option which is still waiting for its
+// argument at the end of the given argument list.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//
+// Returns:
+//   - `*tOptSpec`: The option waiting for its argument or `nil`.
+func (eo *tExpectedOpts) pending(aArgList []string) *tOptSpec {
+	if 2 > len(aArgList) {
+		return nil
+	}
+	word := aArgList[len(aArgList)-1]
+	if !strings.HasPrefix(word, `-`) || strings.Contains(word, `=`) {
+		return nil
+	}
+	for _, w := range aArgList[1:] {
+		if "--" == w {
+			return nil
+		}
+	}
+
+	oal, _ := eo.optArgList(append([]string(nil), aArgList...))
+	if 0 == len(*oal) {
+		return nil
+	}
+	oa := (*oal)[len(*oal)-1]
+	if spec, ok := eo.specs[oa.opt]; ok && (argRequired == spec.argMode) && ("" == oa.arg) {
+		return spec
+	}
+
+	return nil
+} // pending()
+
+// --------------------------------------------------------------------
+// TCommand methods
+
+// `hasCompleter()` returns whether any option of the command or of
+// its subcommands declares a [TCompleter].
+//
+// Returns:
+//   - `bool`: Indicator for whether there's a completer.
+func (c *TCommand) hasCompleter() bool {
+	for _, o := range c.Options {
+		if nil != o.Complete {
+			return true
+		}
+	}
+	for _, sub := range c.Commands {
+		if (nil != sub) && sub.hasCompleter() {
+			return true
+		}
+	}
+
+	return false
+} // hasCompleter()
+
+// `candidates()` returns the completion candidates for the last one
+// of the given words walking down the tree of subcommands.
+//
+// Parameters:
+//   - `aName`: The command's (full) name.
+//   - `aWords`: The words following the command's name with the partial
+//     word to complete as the last one.
+//   - `aParent`: The parser of the parent command (if any).
+//
+// Returns:
+//   - `[]string`: The matching candidates.
+func (c *TCommand) candidates(aName string, aWords []string, aParent *TParser) []string {
+	if 0 == len(aWords) {
+		aWords = []string{""}
+	}
+	last := len(aWords) - 1
+	p := c.parser(append([]string{aName}, aWords[:last]...), aParent)
+
+	if (0 < len(c.Commands)) && (0 < len(p.operands)) {
+		sub := c.command(p.operands[0])
+		if nil == sub {
+			return nil
+		}
+		words := append(append([]string(nil), p.operands[1:]...), aWords[last])
+
+		return sub.candidates(aName+" "+sub.Name, words, p)
+	}
+
+	return p.candidates(aWords)
+} // candidates()
+
+/* _EoF_ */
@@ -0,0 +1,164 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `hostCompleter()` returns host names depending on the `-env` option.
+func hostCompleter(aParser *TParser, aPrefix string) []string {
+	if env, _ := aParser.Lookup("e"); "prod" == env {
+		return []string{"db1.prod", "web1.prod"}
+	}
+
+	return []string{"localhost", "dev1"}
+} // hostCompleter()
+
+func TestTParser_candidates(t *testing.T) {
+	p := NewParserFor([]string{`myprog`}, []TOption{
+		{Pattern: "H,-host:", Complete: hostCompleter},
+		{Pattern: "e,-env:", Choices: []string{"dev", "prod"}},
+		{Pattern: "o,-output:"},
+		{Pattern: "v,-verbose"},
+	})
+
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{"1", []string{`-H`, ``}, []string{"localhost", "dev1"}},
+		{"2", []string{`-H`, `l`}, []string{"localhost"}},
+		{"3", []string{`--env`, `prod`, `--host`, ``}, []string{"db1.prod", "web1.prod"}},
+		{"4", []string{`-e`, `p`}, []string{"prod"}},
+		{"5", []string{`--host=d`}, []string{"--host=dev1"}},
+		{"6", []string{`--h`}, []string{"--host"}},
+		{"7", []string{`-`}, []string{"-H", "--host", "-e", "--env", "-o", "--output", "-v", "--verbose"}},
+		{"8", []string{`-o`, ``}, nil},
+		{"9", []string{`-v`, ``}, nil},
+		{"10", []string{`--`, `-H`, ``}, nil},
+		{"11", nil, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.candidates(tt.words); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TParser.candidates() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_candidates()
+
+func TestTCommand_candidates(t *testing.T) {
+	root := &TCommand{
+		Pattern: "v,-verbose",
+		Commands: []*TCommand{
+			{
+				Name:    "deploy",
+				Options: []TOption{{Pattern: "H,-host:", Complete: hostCompleter}},
+			},
+			{Name: "destroy"},
+			{Name: "list"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{"1", []string{``}, []string{"deploy", "destroy", "list"}},
+		{"2", []string{`-v`, `de`}, []string{"deploy", "destroy"}},
+		{"3", []string{`deploy`, `--host`, `d`}, []string{"dev1"}},
+		{"4", []string{`-v`, `deploy`, `-`}, []string{"-H", "--host"}},
+		{"5", []string{`unknown`, `-`}, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := root.candidates("myprog", tt.words, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TCommand.candidates() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTCommand_candidates()
+
+func TestTParser_Get_complete(t *testing.T) {
+	var buf bytes.Buffer
+	exitCode := -1
+	oldExit, oldWriter := ExitFunc, CompletionWriter
+	ExitFunc = func(aCode int) { exitCode = aCode }
+	CompletionWriter = &buf
+	defer func() {
+		ExitFunc, CompletionWriter = oldExit, oldWriter
+	}()
+
+	p := NewParser([]string{`myprog`, `__complete`, `-H`, ``}, "H,-host:|v").
+		SetCompleter("H", hostCompleter)
+	if err := p.Err(); nil != err {
+		t.Errorf("TParser.Err() = %v, want nil", err)
+	}
+	if opt, _, more := p.Get(); ("?" != opt) || more {
+		t.Errorf("TParser.Get() = %q, %v, want %q, %v", opt, more, "?", false)
+	}
+	if want := "localhost\ndev1\n"; buf.String() != want {
+		t.Errorf("CompletionWriter = %q, want %q", buf.String(), want)
+	}
+	if 0 != exitCode {
+		t.Errorf("ExitFunc() code = %d, want %d", exitCode, 0)
+	}
+
+	buf.Reset()
+	root := &TCommand{
+		Options:  []TOption{{Pattern: "H,-host:", Complete: hostCompleter}},
+		Commands: []*TCommand{{Name: "add"}, {Name: "remove"}},
+	}
+	if err := root.Run([]string{`myprog`, `__complete`, `r`}); nil != err {
+		t.Errorf("TCommand.Run() error = %v, want nil", err)
+	}
+	if want := "remove\n"; buf.String() != want {
+		t.Errorf("CompletionWriter = %q, want %q", buf.String(), want)
+	}
+} // TestTParser_Get_complete()
+
+func TestTParser_isCompleting(t *testing.T) {
+	args := []string{`myprog`, `__complete`, `-v`, `-H`, `d`}
+
+	// Without a completer `__complete` is an operand:
+	res := Parse(args, "H,-host:|v")
+	if res.Completing || !reflect.DeepEqual(res.Operands, []string{`__complete`}) {
+		t.Errorf("Parse() = %v, %q, want %v, %q",
+			res.Completing, res.Operands, false, []string{`__complete`})
+	}
+	var cfg struct {
+		Host  string   `getopts:"H"`
+		Files []string `getopts:",operands"`
+	}
+	if err := Unmarshal([]string{`myprog`, `__complete`, `-H`, `d`}, &cfg); (nil != err) || ("d" != cfg.Host) || (1 != len(cfg.Files)) {
+		t.Errorf("Unmarshal() = %+v, %v", cfg, err)
+	}
+
+	// With a completer the partial commandline is still processed:
+	p := NewParser(args, "H,-host:|v").SetCompleter("H", hostCompleter)
+	if _, ok := p.Lookup("v"); !ok {
+		t.Errorf("TParser.Lookup() = %v, want %v", ok, true)
+	}
+	if res = p.Result(); !res.Completing || res.Help {
+		t.Errorf("TParser.Result() = %v, %v, want %v, %v",
+			res.Completing, res.Help, true, false)
+	}
+} // TestTParser_isCompleting()
+
+/* _EoF_ */
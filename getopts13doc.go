@@ -0,0 +1,358 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TExample` is a sample commandline shown in the documentation.
+	TExample struct {
+		// The sample commandline, e.g. `myprog -o out.txt in.txt`.
+		Command string
+
+		// What the sample commandline does.
+		Description string
+	}
+
+	// `TDoc` generates the reference documentation – a man page or a
+	// Markdown document – from the declared options and subcommands.
+	TDoc struct {
+		// The application's name; defaults to the parser's one.
+		Name string
+
+		// The man page's section; defaults to `1`.
+		Section string
+
+		// The application's version (shown in the man page's header).
+		Version string
+
+		// A one-line description of the application.
+		Summary string
+
+		// A detailed description of the application; paragraphs are
+		// separated by empty lines.
+		Description string
+
+		// Sample commandlines with their descriptions.
+		Examples []TExample
+
+		// The parser whose options to document; ignored if `Command`
+		// is set. If both are `nil` the application's commandline
+		// parser is used.
+		Parser *TParser
+
+		// The tree of commands to document.
+		Command *TCommand
+	}
+
+	// `tDocCommand` is a single (sub)command to document.
+	tDocCommand struct {
+		// The command's full name (e.g. `myprog remote add`)
+		name string
+
+		// A short description of the command
+		description string
+
+		// The parser holding the command's options
+		parser *TParser
+	}
+)
+
+// --------------------------------------------------------------------
+// helper functions
+
+// `mdEscape()` escapes the given text for use in a Markdown table cell.
+//
+// Parameters:
+//   - `aText`: The text to escape.
+//
+// Returns:
+//   - `string`: The escaped text.
+func mdEscape(aText string) string {
+	return strings.ReplaceAll(aText, `|`, `\|`)
+} // mdEscape()
+
+// `roffEscape()` escapes the given text for use in a man page.
+//
+// Parameters:
+//   - `aText`: The text to escape.
+//
+// Returns:
+//   - `string`: The escaped text.
+func roffEscape(aText string) string {
+	aText = strings.NewReplacer(`\`, `\e`, `-`, `\-`).Replace(aText)
+
+	lines := strings.Split(aText, "\n")
+	for idx, line := range lines {
+		if strings.HasPrefix(line, `.`) || strings.HasPrefix(line, `'`) {
+			lines[idx] = `\&` + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+} // roffEscape()
+
+// --------------------------------------------------------------------
+// TDoc methods
+
+// `commands()` returns the commands to document, i.e. the root command
+// followed by all its subcommands (depth first).
+//
+// Returns:
+//   - `[]tDocCommand`: The list of commands.
+func (d TDoc) commands() []tDocCommand {
+	if nil == d.Command {
+		p := d.Parser
+		if nil == p {
+			p = gParser
+		}
+		return []tDocCommand{{name: d.name(p), parser: p}}
+	}
+
+	var (
+		result []tDocCommand
+		walk   func(*TCommand, string, *TParser)
+	)
+	walk = func(aCmd *TCommand, aName string, aParent *TParser) {
+		p := aCmd.parser([]string{aName}, aParent)
+		result = append(result, tDocCommand{
+			name:        aName,
+			description: aCmd.Description,
+			parser:      p,
+		})
+		for _, sub := range aCmd.Commands {
+			if nil != sub {
+				walk(sub, aName+" "+sub.Name, p)
+			}
+		}
+	}
+	walk(d.Command, d.name(nil), nil)
+
+	return result
+} // commands()
+
+// `name()` returns the application's name.
+//
+// Parameters:
+//   - `aParser`: The parser to take the name from if it's not set.
+//
+// Returns:
+//   - `string`: The application's name.
+func (d TDoc) name(aParser *TParser) string {
+	if "" != d.Name {
+		return d.Name
+	}
+	if nil == aParser {
+		aParser = &TParser{}
+	}
+
+	return aParser.name()
+} // name()
+
+// `usesDefault()` returns whether the internal default parser used
+// by [Get] is documented.
+//
+// Returns:
+//   - `bool`: Indicator for whether to guard the default parser.
+func (d TDoc) usesDefault() bool {
+	return (nil == d.Command) && (nil == d.Parser)
+} // usesDefault()
+
+// `Man()` returns the documentation as a man page (in `roff` format).
+//
+// The man page consists of the sections NAME, SYNOPSIS, DESCRIPTION,
+// OPTIONS (including their defaults and environment variables),
+// COMMANDS (with each subcommand's options), ENVIRONMENT, and EXAMPLES
+// – the empty ones being left out. It can be viewed e.g. by
+// `man ./myprog.1`.
+//
+// Returns:
+//   - `string`: The man page.
+func (d TDoc) Man() string {
+	if d.usesDefault() {
+		gMtx.Lock()
+		defer gMtx.Unlock()
+	}
+	var sb strings.Builder
+	cmds := d.commands()
+	root := cmds[0]
+	section := d.Section
+	if "" == section {
+		section = "1"
+	}
+
+	fmt.Fprintf(&sb, ".TH %q %s \"\" %q \"User Commands\"\n",
+		strings.ToUpper(root.name), section, strings.TrimSpace(root.name+" "+d.Version))
+	sb.WriteString(".SH NAME\n")
+	if "" != d.Summary {
+		fmt.Fprintf(&sb, "%s \\- %s\n", roffEscape(root.name), roffEscape(d.Summary))
+	} else {
+		fmt.Fprintf(&sb, "%s\n", roffEscape(root.name))
+	}
+
+	sb.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&sb, ".B %s\n%s\n", roffEscape(root.name), roffEscape(root.synopsis()))
+
+	if desc := strings.TrimSpace(d.Description); "" != desc {
+		sb.WriteString(".SH DESCRIPTION\n")
+		for idx, para := range strings.Split(desc, "\n\n") {
+			if 0 < idx {
+				sb.WriteString(".PP\n")
+			}
+			fmt.Fprintf(&sb, "%s\n", roffEscape(strings.TrimSpace(para)))
+		}
+	}
+
+	manOptions := func(aParser *TParser) {
+		for _, sp := range aParser.iter.expected.order {
+			fmt.Fprintf(&sb, ".TP\n.B %s\n", roffEscape(sp.synopsis()))
+			if usage := sp.usage(aParser.envPrefix); "" != usage {
+				fmt.Fprintf(&sb, "%s\n", roffEscape(usage))
+			}
+		}
+	}
+	if 0 < len(root.parser.iter.expected.order) {
+		sb.WriteString(".SH OPTIONS\n")
+		manOptions(root.parser)
+	}
+
+	if 1 < len(cmds) {
+		sb.WriteString(".SH COMMANDS\n")
+		for _, dc := range cmds[1:] {
+			fmt.Fprintf(&sb, ".SS %q\n", dc.name+" "+dc.synopsis())
+			if "" != dc.description {
+				fmt.Fprintf(&sb, "%s\n", roffEscape(dc.description))
+			}
+			manOptions(dc.parser)
+		}
+	}
+
+	var env strings.Builder
+	for _, dc := range cmds {
+		for _, sp := range dc.parser.iter.expected.order {
+			if name := sp.envName(dc.parser.envPrefix); "" != name {
+				fmt.Fprintf(&env, ".TP\n.B %s\nsets the option %s\n",
+					roffEscape(name), roffEscape(sp.name.flag()))
+			}
+		}
+	}
+	if 0 < env.Len() {
+		sb.WriteString(".SH ENVIRONMENT\n")
+		sb.WriteString(env.String())
+	}
+
+	if 0 < len(d.Examples) {
+		sb.WriteString(".SH EXAMPLES\n")
+		for _, ex := range d.Examples {
+			fmt.Fprintf(&sb, ".TP\n.B %s\n", roffEscape(ex.Command))
+			if "" != ex.Description {
+				fmt.Fprintf(&sb, "%s\n", roffEscape(ex.Description))
+			}
+		}
+	}
+
+	return sb.String()
+} // Man()
+
+// `Markdown()` returns the documentation as a Markdown document.
+//
+// The document consists of the sections Synopsis, Description, Options
+// (a table listing each option's description, default value, and
+// environment variable), Commands (with each subcommand's options),
+// and Examples – the empty ones being left out.
+//
+// Returns:
+//   - `string`: The Markdown document.
+func (d TDoc) Markdown() string {
+	if d.usesDefault() {
+		gMtx.Lock()
+		defer gMtx.Unlock()
+	}
+	var sb strings.Builder
+	cmds := d.commands()
+	root := cmds[0]
+
+	fmt.Fprintf(&sb, "# %s\n\n", root.name)
+	if "" != d.Summary {
+		fmt.Fprintf(&sb, "%s\n\n", d.Summary)
+	}
+	fmt.Fprintf(&sb, "## Synopsis\n\n```\n%s %s\n```\n", root.name, root.synopsis())
+
+	if desc := strings.TrimSpace(d.Description); "" != desc {
+		fmt.Fprintf(&sb, "\n## Description\n\n%s\n", desc)
+	}
+
+	mdOptions := func(aParser *TParser) {
+		order := aParser.iter.expected.order
+		if 0 == len(order) {
+			return
+		}
+		sb.WriteString("\n| Option | Description | Default | Environment |\n")
+		sb.WriteString("|--------|-------------|---------|-------------|\n")
+		for _, sp := range order {
+			def, env := "", ""
+			if "" != sp.defValue {
+				def = "`" + mdEscape(sp.defValue) + "`"
+			}
+			if name := sp.envName(aParser.envPrefix); "" != name {
+				env = "`" + mdEscape(name) + "`"
+			}
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s |\n",
+				mdEscape(sp.synopsis()), mdEscape(sp.summary()), def, env)
+		}
+	}
+	if 0 < len(root.parser.iter.expected.order) {
+		sb.WriteString("\n## Options\n")
+		mdOptions(root.parser)
+	}
+
+	if 1 < len(cmds) {
+		sb.WriteString("\n## Commands\n")
+		for _, dc := range cmds[1:] {
+			fmt.Fprintf(&sb, "\n### %s\n\n```\n%s %s\n```\n", dc.name, dc.name, dc.synopsis())
+			if "" != dc.description {
+				fmt.Fprintf(&sb, "\n%s\n", dc.description)
+			}
+			mdOptions(dc.parser)
+		}
+	}
+
+	if 0 < len(d.Examples) {
+		sb.WriteString("\n## Examples\n")
+		for _, ex := range d.Examples {
+			fmt.Fprintf(&sb, "\n```\n%s\n```\n", ex.Command)
+			if "" != ex.Description {
+				fmt.Fprintf(&sb, "\n%s\n", ex.Description)
+			}
+		}
+	}
+
+	return sb.String()
+} // Markdown()
+
+// --------------------------------------------------------------------
+// tDocCommand methods
+
+// `synopsis()` returns the usage line of the command.
+//
+// Returns:
+//   - `string`: The usage line without the command's name.
+func (dc tDocCommand) synopsis() string {
+	if 0 < len(dc.parser.commands) {
+		return "[OPTION]... COMMAND [ARG]..."
+	}
+
+	return "[OPTION]..."
+} // synopsis()
+
+/* _EoF_ */
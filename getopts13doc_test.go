@@ -0,0 +1,126 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepDoc() TDoc {
+	return TDoc{
+		Name:        "myprog",
+		Version:     "1.0",
+		Summary:     "process some files",
+		Description: "Reads the input files.\n\nWrites the result.",
+		Examples: []TExample{
+			{Command: "myprog -v add a.txt", Description: "add a file"},
+		},
+		Command: &TCommand{
+			Options: []TOption{
+				{Pattern: "o,-output:", Arg: "FILE", Description: "write to FILE", Default: "out.txt", Env: "MYPROG_OUTPUT"},
+				{Pattern: "v,-verbose", Description: "be verbose"},
+			},
+			Commands: []*TCommand{
+				{Name: "add", Description: "add files", Pattern: "f,-force"},
+			},
+		},
+	}
+} // prepDoc()
+
+func Test_roffEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"1", "plain text", "plain text"},
+		{"2", "-o, --output", `\-o, \-\-output`},
+		{"3", `a\b`, `a\eb`},
+		{"4", ".start\n'quote", "\\&.start\n\\&'quote"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roffEscape(tt.text); got != tt.want {
+				t.Errorf("%q: roffEscape() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_roffEscape()
+
+func TestTDoc_Man(t *testing.T) {
+	want := `.TH "MYPROG" 1 "" "myprog 1.0" "User Commands"
+.SH NAME
+myprog \- process some files
+.SH SYNOPSIS
+.B myprog
+[OPTION]... COMMAND [ARG]...
+.SH DESCRIPTION
+Reads the input files.
+.PP
+Writes the result.
+.SH OPTIONS
+.TP
+.B \-o, \-\-output=FILE
+write to FILE (default: out.txt) (env: MYPROG_OUTPUT)
+.TP
+.B \-v, \-\-verbose
+be verbose
+.SH COMMANDS
+.SS "myprog add [OPTION]..."
+add files
+.TP
+.B \-f, \-\-force
+.SH ENVIRONMENT
+.TP
+.B MYPROG_OUTPUT
+sets the option \-o
+.SH EXAMPLES
+.TP
+.B myprog \-v add a.txt
+add a file
+`
+	if got := prepDoc().Man(); got != want {
+		t.Errorf("TDoc.Man() =\n%s\n want \n%s", got, want)
+	}
+} // TestTDoc_Man()
+
+func TestTDoc_Markdown(t *testing.T) {
+	want := "# myprog\n\nprocess some files\n\n" +
+		"## Synopsis\n\n```\nmyprog [OPTION]... COMMAND [ARG]...\n```\n\n" +
+		"## Description\n\nReads the input files.\n\nWrites the result.\n\n" +
+		"## Options\n\n" +
+		"| Option | Description | Default | Environment |\n" +
+		"|--------|-------------|---------|-------------|\n" +
+		"| `-o, --output=FILE` | write to FILE | `out.txt` | `MYPROG_OUTPUT` |\n" +
+		"| `-v, --verbose` | be verbose |  |  |\n\n" +
+		"## Commands\n\n### myprog add\n\n```\nmyprog add [OPTION]...\n```\n\nadd files\n\n" +
+		"| Option | Description | Default | Environment |\n" +
+		"|--------|-------------|---------|-------------|\n" +
+		"| `-f, --force` |  |  |  |\n\n" +
+		"## Examples\n\n```\nmyprog -v add a.txt\n```\n\nadd a file\n"
+
+	if got := prepDoc().Markdown(); got != want {
+		t.Errorf("TDoc.Markdown() =\n%s\n want \n%s", got, want)
+	}
+
+	doc := TDoc{Parser: NewParser([]string{`/usr/bin/tool`}, "a:=1|b")}
+	want = "# tool\n\n## Synopsis\n\n```\ntool [OPTION]...\n```\n\n## Options\n\n" +
+		"| Option | Description | Default | Environment |\n" +
+		"|--------|-------------|---------|-------------|\n" +
+		"| `-a ARG` |  | `1` |  |\n" +
+		"| `-b` |  |  |  |\n"
+	if got := doc.Markdown(); got != want {
+		t.Errorf("TDoc.Markdown() =\n%s\n want \n%s", got, want)
+	}
+} // TestTDoc_Markdown()
+
+/* _EoF_ */
@@ -0,0 +1,276 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TOptValue` is a single option found with its argument.
+	TOptValue struct {
+		// The option's canonical name as used in the pattern
+		// (e.g. `o` for the option `o,-output:`).
+		Opt string
+
+		// The option's argument (empty for a flag option).
+		Arg TArg
+
+		// Where the option's value came from.
+		Source TSource
+	}
+
+	// `TResult` is a snapshot of the outcome of processing an argument
+	// list: the options found, the operands, and the problems found.
+	//
+	// It's meant for inspecting the processing of arbitrary argument
+	// lists e.g. in an application's tests without touching `os.Args`,
+	// environment variables, or the internal default parser used by
+	// [Get]; see [Parse].
+	//
+	// A result is never changed once it's set up. Hence – other than
+	// a [TParser] – its methods are safe for concurrent use, e.g. by
+	// several request handlers and background workers (as long as the
+	// caller doesn't modify its fields).
+	TResult struct {
+		// All options as returned by [TParser.Get], i.e. the ones
+		// given on the commandline in their order followed by those
+		// set by the environment, a configuration file, or their
+		// default value.
+		Options []TOptValue
+
+		// The commandline words which are neither options nor arguments.
+		Operands []string
+
+		// The problems found with the options (empty if there are none).
+		Errors []error
+
+		// Whether a help option was given.
+		Help bool
+
+		// Whether the dynamic completion was requested (see
+		// [TCompleter]), i.e. the commandline is a partial one
+		// to be completed by [TParser.Get] or [TCommand.Run]
+		// rather than to be processed; it's not checked then.
+		Completing bool
+
+		// The expected options by all their names (for the lookups)
+		specs tOptSpecs
+	}
+)
+
+// --------------------------------------------------------------------
+// Public functions
+
+// `Parse()` processes the given argument list according to the given
+// pattern and returns the outcome.
+//
+// Other than [Get] no option is handled by the processing, i.e. neither
+// a help text is shown nor is the application terminated. Hence it can
+// be used to check an application's option handling in its tests:
+//
+//	res := getopts.Parse([]string{"myprog", "-v", "in.txt"}, "v|-verbose")
+//	if 0 < len(res.Errors) {
+//		t.Errorf("unexpected errors: %v", res.Errors)
+//	}
+//
+// The first element of `aArgList` is expected to be the application's
+// name (like in `os.Args`) and is ignored.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//   - `aPattern`: The pattern declaring which commandline options to expect.
+//
+// Returns:
+//   - `TResult`: The outcome of the processing.
+func Parse(aArgList []string, aPattern string) TResult {
+	return NewParser(aArgList, aPattern).Result()
+} // Parse()
+
+// `ParseFor()` processes the given argument list according to the
+// declared options and returns the outcome.
+//
+// This is the counterpart of [Parse] for option declarations as used
+// with [NewParserFor].
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//   - `aOptions`: The declarations of the commandline options to expect.
+//
+// Returns:
+//   - `TResult`: The outcome of the processing.
+func ParseFor(aArgList []string, aOptions []TOption) TResult {
+	return NewParserFor(aArgList, aOptions).Result()
+} // ParseFor()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `Result()` returns a snapshot of the parser's outcome.
+//
+// The snapshot doesn't depend on the parser's iteration state and
+// isn't affected by later changes of the parser.
+//
+// Returns:
+//   - `TResult`: The outcome of the processing.
+func (p *TParser) Result() TResult {
+	eo := p.iter.expected
+	result := TResult{
+		Options:    make([]TOptValue, 0, len(*p.iter.optArgs)),
+		Operands:   p.Operands(),
+		Errors:     p.Errors(),
+		Help:       p.IsHelp(),
+		Completing: p.isCompleting(),
+	}
+	// Copy the specs so that later changes of the parser don't
+	// affect the snapshot:
+	result.specs = make(tOptSpecs, len(eo.specs))
+	copies := make(map[*tOptSpec]*tOptSpec, len(eo.order))
+	for name, spec := range eo.specs {
+		if _, ok := copies[spec]; !ok {
+			cp := *spec
+			copies[spec] = &cp
+		}
+		result.specs[name] = copies[spec]
+	}
+
+	for _, oa := range *p.iter.optArgs {
+		if (completionOpt == oa.opt) || (nil != eo.check(oa.opt, oa.arg)) {
+			// Neither invalid options nor the hidden
+			// completion option are returned by `Get()`.
+			continue
+		}
+		result.Options = append(result.Options, TOptValue{
+			Opt:    string(oa.opt),
+			Arg:    oa.arg,
+			Source: p.sources[oa.opt],
+		})
+	}
+
+	return result
+} // Result()
+
+// --------------------------------------------------------------------
+// TResult methods
+
+// `canonical()` returns the canonical name of the given option.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases).
+//
+// Returns:
+//   - `string`: The option's canonical name.
+func (r TResult) canonical(aOpt string) string {
+	if spec, ok := r.specs[tOpt(aOpt)]; ok {
+		return string(spec.name)
+	}
+
+	return aOpt
+} // canonical()
+
+// `values()` returns the arguments of all occurrences of the given
+// option in their commandline order.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases).
+//
+// Returns:
+//   - `[]TArg`: The list of arguments.
+func (r TResult) values(aOpt string) []TArg {
+	aOpt = r.canonical(aOpt)
+
+	var result []TArg
+	for _, ov := range r.Options {
+		if aOpt == ov.Opt {
+			result = append(result, ov.Arg)
+		}
+	}
+
+	return result
+} // values()
+
+// `Count()` returns how often the given option was used.
+//
+// See [TParser.Count] for details.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `int`: The number of times the option was given.
+func (r TResult) Count(aOpt string) int {
+	return countArgs(r.values(aOpt))
+} // Count()
+
+// `Err()` returns all problems found with the options.
+//
+// Returns:
+//   - `error`: `nil` if there are no problems, or all errors joined.
+func (r TResult) Err() error {
+	return errors.Join(r.Errors...)
+} // Err()
+
+// `Lookup()` returns the argument of the given option.
+//
+// See [TParser.Lookup] for details.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `TArg`: The option's argument.
+//   - `bool`: Indicator for whether the option has a value.
+func (r TResult) Lookup(aOpt string) (TArg, bool) {
+	args := r.values(aOpt)
+	if 0 == len(args) {
+		return TArg(""), false
+	}
+
+	return args[len(args)-1], true
+} // Lookup()
+
+// `Source()` returns where the value of the given option came from.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `TSource`: The option's source ([SourceNone] if it's not given at all).
+func (r TResult) Source(aOpt string) TSource {
+	aOpt = r.canonical(aOpt)
+	for _, ov := range r.Options {
+		if aOpt == ov.Opt {
+			return ov.Source
+		}
+	}
+
+	return SourceNone
+} // Source()
+
+// `Values()` returns the arguments of the given option.
+//
+// See [TParser.Values] for details.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `[]TArg`: The option's arguments (empty if the option wasn't given).
+func (r TResult) Values(aOpt string) []TArg {
+	spec, ok := r.specs[tOpt(aOpt)]
+	if !ok {
+		return []TArg{}
+	}
+
+	// Return a copy so the caller can't change the result:
+	return append([]TArg{}, spec.repeated(r.values(aOpt))...)
+} // Values()
+
+/* _EoF_ */
@@ -0,0 +1,213 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestParse(t *testing.T) {
+	p1 := "o,-output:|v,-verbose|p:=80"
+
+	a1 := []string{`app`, `-v`, `--output=x.txt`, `in.txt`}
+	w1 := TResult{
+		Options: []TOptValue{
+			{"v", "", SourceCommandline},
+			{"o", "x.txt", SourceCommandline},
+			{"p", "80", SourceDefault},
+		},
+		Operands: []string{`in.txt`},
+		Errors:   []error{},
+	}
+
+	a2 := []string{`app`, `-x`, `-o`}
+	w2 := TResult{
+		Options: []TOptValue{
+			{"p", "80", SourceDefault},
+		},
+		Operands: []string{},
+		Errors: []error{
+			ErrUnknownOption{Opt: "x"},
+			ErrMissingArgument{Opt: "o"},
+		},
+	}
+
+	a3 := []string{`app`, `--help`}
+	w3 := TResult{
+		Options: []TOptValue{
+			{"-help", "", SourceCommandline},
+		},
+		Operands: []string{},
+		Errors:   []error{},
+		Help:     true,
+	}
+
+	a4 := []string{`app`, `-v`, `--generate-completion`, `bash`}
+	w4 := TResult{
+		Options: []TOptValue{
+			{"v", "", SourceCommandline},
+			{"p", "80", SourceDefault},
+		},
+		Operands: []string{},
+		Errors:   []error{},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		pattern string
+		want    TResult
+	}{
+		{"1", a1, p1, w1},
+		{"2", a2, p1, w2},
+		{"3", a3, "", w3},
+		{"4", a4, p1, w4},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.args, tt.pattern)
+			got.specs = nil // internal lookup data
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: Parse() =\n%#v\nwant\n%#v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestParse()
+
+func TestParseFor(t *testing.T) {
+	opts := []TOption{
+		{Pattern: "f,-format:", Choices: []string{"json", "yaml"}},
+	}
+	got := ParseFor([]string{`app`, `-f`, `xml`}, opts)
+
+	var err ErrInvalidArgument
+	if (1 != len(got.Errors)) || !errors.As(got.Errors[0], &err) {
+		t.Errorf("ParseFor() Errors = %v, want %T", got.Errors, err)
+	}
+	if w := []TOptValue{{"f", "xml", SourceCommandline}}; !reflect.DeepEqual(got.Options, w) {
+		t.Errorf("ParseFor() Options = %v, want %v", got.Options, w)
+	}
+} // TestParseFor()
+
+func TestTParser_Result(t *testing.T) {
+	p := NewParser([]string{`app`, `-a`, `-b`, `x`}, "a|b:")
+	want := p.Result()
+
+	// Neither the iteration nor a new pattern change the snapshot:
+	p.Get()
+	p.Get()
+	p.setPattern("c")
+	if !reflect.DeepEqual(want.Options, []TOptValue{{"a", "", SourceCommandline}, {"b", "x", SourceCommandline}}) {
+		t.Errorf("TParser.Result() Options = %v", want.Options)
+	}
+	if 2 != len(p.Result().Errors) {
+		t.Errorf("TParser.Result() Errors = %v, want 2 errors", p.Result().Errors)
+	}
+} // TestTParser_Result()
+
+func TestTResult_lookups(t *testing.T) {
+	p := NewParser([]string{`app`, `-vv`, `--tags=a,b`, `-t`, `c`, `-o`, `x`, `--output`, `y`}, "o,-output:|t,-tags:*,|v,-verbose|p:=80")
+	res := p.Result()
+
+	tests := []struct {
+		name       string
+		opt        string
+		wantArg    TArg
+		wantOK     bool
+		wantValues []TArg
+		wantCount  int
+		wantSource TSource
+	}{
+		{"1", "-output", "y", true, []TArg{"y"}, 2, SourceCommandline},
+		{"2", "-tags", "c", true, []TArg{"a", "b", "c"}, 2, SourceCommandline},
+		{"3", "v", "", true, []TArg{""}, 2, SourceCommandline},
+		{"4", "p", "80", true, []TArg{"80"}, 80, SourceDefault},
+		{"5", "x", "", false, []TArg{}, 0, SourceNone},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotArg, gotOK := res.Lookup(tt.opt)
+			if (gotArg != tt.wantArg) || (gotOK != tt.wantOK) {
+				t.Errorf("%q: TResult.Lookup() = %q, %t, want %q, %t",
+					tt.name, gotArg, gotOK, tt.wantArg, tt.wantOK)
+			}
+			if got := res.Values(tt.opt); !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("%q: TResult.Values() = %q, want %q",
+					tt.name, got, tt.wantValues)
+			}
+			if got := res.Count(tt.opt); got != tt.wantCount {
+				t.Errorf("%q: TResult.Count() = %d, want %d",
+					tt.name, got, tt.wantCount)
+			}
+			if got := res.Source(tt.opt); got != tt.wantSource {
+				t.Errorf("%q: TResult.Source() = %v, want %v",
+					tt.name, got, tt.wantSource)
+			}
+			// The parser agrees with its snapshot:
+			if got := p.Values(tt.opt); !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("%q: TParser.Values() = %q, want %q",
+					tt.name, got, tt.wantValues)
+			}
+		})
+	}
+} // TestTResult_lookups()
+
+func TestTResult_concurrent(t *testing.T) {
+	defer realInit(gParser.args)
+	realInit([]string{`app`, `-v`, `--port`, `8080`, `in.txt`})
+	pattern := "v|-port:"
+	res := Result(pattern)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; 16 > i; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if arg, _ := res.Lookup("-port"); 8080 != arg.Int() {
+				errs <- fmt.Errorf("TResult.Lookup() = %q, want %q", arg, "8080")
+			}
+			if 1 != res.Count("v") {
+				errs <- fmt.Errorf("TResult.Count() = %d, want %d", res.Count("v"), 1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// The guarded internal parser:
+			Get(pattern)
+			if ops := Operands(); !reflect.DeepEqual(ops, []string{`in.txt`}) {
+				errs <- fmt.Errorf("Operands() = %q, want %q", ops, "in.txt")
+			}
+			_ = Errors()
+
+			var cfg struct {
+				V    bool `getopts:"v"`
+				Port int  `getopts:"-port"`
+			}
+			if err := Bind(&cfg); (nil != err) || (8080 != cfg.Port) {
+				errs <- fmt.Errorf("Bind() = %+v, %v, want Port %d", cfg, err, 8080)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+} // TestTResult_concurrent()
+
+/* _EoF_ */
@@ -9,25 +9,126 @@ package getopts
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
 
 type (
-	// A map of the _expected_ options indicating whether
-	// it needs an argument to work.
-	tArgBool map[tOpt]bool
+	// `tArgMode` tells whether an option takes an argument.
+	tArgMode uint8
+
+	// `tOptSpec` describes a single _expected_ option.
+	tOptSpec struct {
+		// The option's canonical name (without its first leading hyphen)
+		name tOpt
+
+		// Other names (aliases) of the option
+		aliases []tOpt
+
+		// Whether the option takes an argument
+		argMode tArgMode
+
+		// Whether the option may be given several times
+		repeat bool
+
+		// Whether comma separated arguments are split up
+		split bool
+
+		// Whether a flag accepts an attached argument (e.g. `--debug=no`)
+		valued bool
+
+		// A short description of the option (for the help text)
+		description string
+
+		// The name of the option's argument (for the help text)
+		placeholder string
+
+		// The option's default value
+		defValue string
+
+		// The environment variable providing a fallback value
+		env string
+
+		// Whether the option must be given
+		required bool
+
+		// The allowed arguments (if restricted)
+		choices []string
+
+		// The checks of the option's arguments
+		validators []TValidator
+
+		// The provider of completion candidates for the argument
+		completer TCompleter
+	}
+
+	// A map of the _expected_ options and their respective description.
+	tOptSpecs map[tOpt]*tOptSpec
 
 	// A map of the known/expected options and argument requirement
 	tExpectedOpts struct {
 		// List of all _expected_ commandline options
-		argBool tArgBool
+		specs tOptSpecs
+
+		// The expected options in the order of their declaration
+		order []*tOptSpec
+
+		// Whether the first operand ends the options processing
+		// (used for commands with subcommands)
+		stopAtOperand bool
+
+		// Whether an empty commandline is taken as is instead of
+		// as a help request (used for subcommands)
+		noHelpDefault bool
 
 		// A previously used options pattern
 		previous string
 	}
 )
 
+const (
+	// The option is a flag not taking any argument.
+	argNone tArgMode = iota
+
+	// The option requires an argument (pattern `o:`).
+	argRequired
+
+	// The option may take an argument (pattern `o::`).
+	argOptional
+)
+
+// --------------------------------------------------------------------
+// tOptSpec methods
+
+// `isHelp()` returns whether the option is a help request,
+// i.e. whether one of its names is `h` or `-help`.
+//
+// Returns:
+//   - `bool`: Indicator for whether the option requests help.
+func (sp *tOptSpec) isHelp() bool {
+	for _, name := range sp.names() {
+		switch name {
+		case `h`, `-help`:
+			return true
+		}
+	}
+
+	return false
+} // isHelp()
+
+// `names()` returns all names of the option, i.e. its canonical name
+// followed by its aliases.
+//
+// Returns:
+//   - `[]tOpt`: The option's names.
+func (sp *tOptSpec) names() []tOpt {
+	return append([]tOpt{sp.name}, sp.aliases...)
+} // names()
+
+// --------------------------------------------------------------------
+// tExpectedOpts constructor
+
 // `newExpectedOpts()` sets up a instance of `tExpectedOpts`.
 //
 // This function is a first step to manage the expected commandline
@@ -39,53 +140,416 @@ type (
 // Returns:
 //   - `*tExpectedOpts`: The requested `tExpectedArgs` instance.
 func newExpectedOpts(aPattern string) *tExpectedOpts {
-	var eo *tExpectedOpts
+	eo := &tExpectedOpts{
+		specs: make(tOptSpecs),
+	}
+
+	return eo.parse(aPattern)
+} // newExpectedOpts()
+
+// `isEnvName()` returns whether the given text is a valid name of an
+// environment variable, i.e. letters, digits, and underscores not
+// starting with a digit.
+//
+// Parameters:
+//   - `aName`: The text to check.
+//
+// Returns:
+//   - `bool`: Indicator for whether the text is a variable's name.
+func isEnvName(aName string) bool {
+	if "" == aName {
+		return false
+	}
+	for idx, r := range aName {
+		switch {
+		case ('_' == r) || (('a' <= r) && ('z' >= r)) || (('A' <= r) && ('Z' >= r)):
+		case ('0' <= r) && ('9' >= r) && (0 < idx):
+		default:
+			return false
+		}
+	}
 
-	if nil == gIterator {
-		eo = &tExpectedOpts{
-			argBool: make(tArgBool),
+	return true
+} // isEnvName()
+
+// `newOptSpec()` parses a single element of an options pattern.
+//
+// See [tExpectedOpts.parse] for the syntax of the pattern's elements.
+//
+// Parameters:
+//   - `aElement`: The pattern element declaring an option (e.g. `o,-output:`).
+//
+// Returns:
+//   - `*tOptSpec`: The option's description or `nil` for an empty element.
+func newOptSpec(aElement string) *tOptSpec {
+	opt := aElement
+	if "" == opt {
+		// leading/trailing or orphaned pipe separator
+		return nil
+	}
+
+	// Look for leading colons and spaces to determine whether
+	// to remove leasing garbage:
+	pos, optL := 0, len(opt)
+	for (pos < optL) && ((`:` == string(opt[pos])) || (` ` == string(opt[pos]))) {
+		pos++
+	}
+	if 0 < pos {
+		if pos == optL {
+			return nil // ignore empty option
 		}
-	} else {
-		if nil == gIterator.expected {
-			eo = &tExpectedOpts{
-				argBool: make(tArgBool),
+		opt = opt[pos:]
+		optL = len(opt)
+	}
+
+	// An environment variable providing a fallback value
+	// follows a `$` sign (e.g. `-output:$MYPROG_OUTPUT`);
+	// any other `$` belongs to e.g. a default value (`=$5`):
+	var env string
+	if pos = strings.LastIndexByte(opt, '$'); 0 <= pos {
+		if name := strings.TrimSpace(opt[pos+1:]); ("" == name) || isEnvName(name) {
+			if 0 == pos {
+				return nil // ignore empty option
 			}
-			gIterator.expected = eo.parse(aPattern)
+			env = name
+			opt = opt[:pos]
+			optL = len(opt)
+		}
+	}
 
-			return eo
+	// A default value follows an `=` sign (e.g. `-port:=8080`):
+	var defValue string
+	if pos = strings.IndexByte(opt, '='); 0 <= pos {
+		defValue = strings.TrimSpace(opt[pos+1:])
+		if 0 == pos {
+			return nil // ignore empty option
 		}
+		opt = opt[:pos]
+		optL = len(opt)
+	}
+
+	// Now, look for trailing colons and spaces to determine whether
+	// the option requires (one colon) or accepts (two colons)
+	// an argument, and for the list modifiers:
+	pos = optL
+	colons := 0
+	repeat, split, required := false, false, false
+argLoop:
+	for 0 < pos {
+		// for (0 < pos) && ((`:` == string(opt[pos-1])) || (` ` == string(opt[pos-1]))) {
+		switch string(opt[pos-1]) {
+		case `:`:
+			colons++
+			pos--
+
+		case `*`, `+`:
+			repeat = true
+			pos--
+
+		case `,`:
+			repeat, split = true, true
+			pos--
 
-		eo = gIterator.expected
-		if eo.previous == aPattern {
-			return eo
+		case `!`:
+			required = true
+			pos--
+
+		case ` `:
+			pos--
+
+		default:
+			break argLoop
 		}
 	}
 
-	return eo.parse(aPattern)
-} // newExpectedOpts()
+	if pos < optL {
+		if 0 == pos {
+			return nil // ignore empty option
+		}
+		opt = opt[:pos]
+	}
+
+	// Split the option's name(s) by `,` into canonical name
+	// and aliases:
+	var names []tOpt
+	for _, name := range strings.Split(opt, `,`) {
+		if name = strings.TrimSpace(name); "" != name {
+			names = append(names, tOpt(name))
+		}
+	}
+	if 0 == len(names) {
+		return nil // ignore empty option
+	}
+
+	spec := &tOptSpec{
+		name:     names[0],
+		aliases:  names[1:],
+		argMode:  argNone,
+		repeat:   repeat,
+		split:    split,
+		defValue: defValue,
+		env:      env,
+		required: required,
+	}
+	switch colons {
+	case 0:
+		// flag option
+	case 1:
+		spec.argMode = argRequired
+	default:
+		spec.argMode = argOptional
+	}
+
+	return spec
+} // newOptSpec()
+
+// --------------------------------------------------------------------
+// tExpectedOpts methods
 
-// `isValid()` checks if a given commandline option is recognised.
+// `add()` registers the given option under all its names.
 //
-// This function takes a commandline option name as input and returns
-// a boolean value indicating whether the option requires an argument.
+// Parameters:
+//   - `aSpec`: The option's description.
+func (eo *tExpectedOpts) add(aSpec *tOptSpec) {
+	for _, name := range aSpec.names() {
+		eo.specs[name] = aSpec
+	}
+	eo.order = append(eo.order, aSpec)
+} // add()
+
+// `canonical()` returns the canonical name of the given option.
+//
+// If `aOpt` is an alias of a declared option the option's canonical
+// name is returned, otherwise `aOpt` itself.
+//
+// Parameters:
+//   - `aOpt`: The commandline option name to look up.
+//
+// Returns:
+//   - `tOpt`: The option's canonical name.
+func (eo tExpectedOpts) canonical(aOpt tOpt) tOpt {
+	if spec, ok := eo.specs[aOpt]; ok {
+		return spec.name
+	}
+
+	return aOpt
+} // canonical()
+
+// `check()` checks if a given commandline option is recognised and
+// comes with its required argument.
+//
+// A flag option doesn't accept an argument unless it's repeatable
+// (e.g. `v*` counting its uses like `--verbose=2`) or bound to a
+// boolean or counting struct field (see [Unmarshal]).
 //
 // Parameters:
 //   - `aOpt`: The commandline option name to check.
-//   - `rArg`: The option's argument to check.
+//   - `aArg`: The option's argument to check.
 //
 // Returns:
-//   - `bool`: Indicator for whether the option is recognised.
-func (eo tExpectedOpts) isValid(aOpt tOpt, rArg TArg) bool {
-	needArg, valid := eo.argBool[aOpt]
-	if needArg && ("" == string(rArg)) {
-		return false
+//   - `error`: `nil` if valid, [ErrUnknownOption], [ErrMissingArgument],
+//     or [ErrUnexpectedArgument] otherwise.
+func (eo tExpectedOpts) check(aOpt tOpt, aArg TArg) error {
+	spec, valid := eo.specs[aOpt]
+	if !valid {
+		return ErrUnknownOption{Opt: string(aOpt)}
+	}
+	if (argRequired == spec.argMode) && ("" == string(aArg)) {
+		return ErrMissingArgument{Opt: string(aOpt)}
+	}
+	if (argNone == spec.argMode) && ("" != string(aArg)) && !spec.repeat && !spec.valued {
+		return ErrUnexpectedArgument{Opt: string(aOpt), Arg: string(aArg)}
 	}
 
-	return valid
-} // isValid()
+	return nil
+} // check()
+
+// `optArgList()` creates a new option/argument list based on `aArgList`
+// using the expected options to interpret the commandline.
+//
+// The method follows the POSIX conventions for short options: Several
+// flag options can be clustered behind a single hyphen (e.g. `-xzf` is
+// the same as `-x -z -f`) and an option requiring an argument takes
+// the remainder of its word as argument (e.g. `-ofile` is the same as
+// `-o file`). Only if there's no such remainder, the next word is used
+// as the argument – whatever it looks like, i.e. `-n -5` gives the
+// option `n` the argument `-5`. A word with a single hyphen which is
+// declared as a whole (e.g. `help` for `-help`) is not split up.
+//
+// Long options may have their argument attached after an equal sign
+// (e.g. `--output=file.txt`); the word is split at the first `=`.
+// Otherwise the next word is used as the argument only if the option
+// is declared to require one.
+//
+// An option with an optional argument only takes an attached argument
+// (i.e. `-ofile` or `--output=file`) but never the next word.
+//
+// Declared options are added by their canonical name, i.e. an alias
+// like `--input` for `i,-input:` is added as `i`. Options which are not
+// declared or miss their required argument are added as given (e.g.
+// `-input`) so that they can be reported later on by the name the user
+// actually typed.
+//
+// All words that are neither options nor arguments are returned as
+// operands in their original order. The word `--` ends the processing
+// of options, i.e. all following words are operands. A single `-`
+// (commonly used for `stdin`/`stdout`) is an operand as well.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//
+// Returns:
+//   - `*tOptArgList`: A pointer to the newly created argument list.
+//   - `[]string`: The list of operands.
+func (eo *tExpectedOpts) optArgList(aArgList []string) (*tOptArgList, []string) {
+	oal := make(tOptArgList, 0, len(aArgList))
+	operands := make([]string, 0, len(aArgList))
+
+	if 1 >= len(aArgList) {
+		if eo.noHelpDefault {
+			return &oal, operands
+		}
+		var empty TArg
+		// Set up some standard default options (if they are
+		// expected at all) – each by its canonical name and only
+		// once if e.g. `h,-help` are aliases:
+		var seen *tOptSpec
+		for _, opt := range []tOpt{`h`, `-help`} {
+			if spec, ok := eo.specs[opt]; ok && (seen != spec) {
+				oal = append(oal, tOptArg{spec.name, empty})
+				seen = spec
+			}
+		}
+		// nothing more to do here:
+		return &oal, operands
+	}
+
+	// Get the commandline arguments without the app's path/name
+	// but with an added (empty) argument to allow for a peek ahead.
+	optList := make([]string, len(aArgList), len(aArgList)+1)
+	copy(optList, aArgList[1:])
+	optList[len(optList)-1] = ""
+	oLen := len(optList) - 1
+
+	// `peekArg()` returns the word following the current one as the
+	// required argument – whatever it looks like (like POSIX does).
+	peekArg := func(aIdx int) (TArg, int) {
+		if aIdx+1 >= oLen {
+			// The end of the list
+			return TArg(""), aIdx
+		}
+
+		return TArg(optList[aIdx+1]), aIdx + 1
+	} // peekArg()
+
+	// `addOpt()` appends the option by its canonical name unless it's
+	// invalid: then it keeps the name given to report it as typed.
+	addOpt := func(aOpt tOpt, aArg TArg) {
+		if nil == eo.check(aOpt, aArg) {
+			aOpt = eo.canonical(aOpt)
+		}
+		oal = append(oal, tOptArg{aOpt, aArg})
+	} // addOpt()
+
+	for i := 0; i < oLen; i++ {
+		o := optList[i]
+		if (len(o) < 2) || ('-' != o[0]) {
+			// We expect at least `-o` i.e. two characters,
+			// everything else is an operand.
+			if eo.stopAtOperand {
+				// All remaining words belong to a subcommand:
+				operands = append(operands, optList[i:oLen]...)
+				break
+			}
+			operands = append(operands, o)
+			continue
+		}
+		if "--" == o {
+			// End of options: all remaining words are operands
+			operands = append(operands, optList[i+1:oLen]...)
+			break
+		}
+		o = o[1:]
+
+		if '-' == o[0] {
+			// A long option which might have its argument
+			// attached like `--name=value`:
+			if name, value, found := strings.Cut(o, "="); found {
+				addOpt(tOpt(name), TArg(value))
+				continue
+			}
+		}
+
+		spec, known := eo.specs[tOpt(o)]
+		if known || ('-' == o[0]) || (1 == utf8.RuneCountInString(o)) {
+			// A long option or a single short option
+			var arg TArg
+			if known && (argRequired == spec.argMode) {
+				arg, i = peekArg(i)
+			}
+			addOpt(tOpt(o), arg)
+			continue
+		}
+
+		first, _ := utf8.DecodeRuneInString(o)
+		if _, known = eo.specs[tOpt(first)]; !known {
+			// Neither a known option nor a cluster of them:
+			addOpt(tOpt(o), TArg(""))
+			continue
+		}
+
+		// A cluster of short options:
+		for pos, r := range o {
+			opt := tOpt(r)
+			if spec, known = eo.specs[opt]; !known || (argNone == spec.argMode) {
+				// A flag option or an unknown one
+				addOpt(opt, TArg(""))
+				continue
+			}
+
+			// The rest of the word is the option's argument:
+			arg := TArg(o[pos+utf8.RuneLen(r):])
+			if ("" == arg) && (argRequired == spec.argMode) {
+				arg, i = peekArg(i)
+			}
+			addOpt(opt, arg)
+			break
+		}
+	}
+
+	return &oal, operands
+} // optArgList()
 
 // `parse()` parses the provided pattern and updates the expected arguments.
 //
+// The options are separated by `|` (pipe) characters. An option name
+// followed by a single colon (e.g. `o:`) requires an argument, while
+// two colons (e.g. `o::`) declare an optional argument. An option name
+// without any colon is a flag option not taking an argument.
+//
+// Several names separated by commas declare aliases of the same option
+// (e.g. `i,-input:`); the first one is the option's canonical name.
+//
+// A trailing `*` or `+` declares an option that may be given several
+// times (e.g. `I:*` or `-include:+`) with all its arguments collected.
+// A repeatable flag option (e.g. `v*`) counts its uses and may be given
+// the count directly (e.g. `--verbose=2`, see [TParser.Count]) while
+// any other flag option doesn't accept an argument.
+// A further trailing `,` (e.g. `-tags:*,`) splits each argument at its
+// commas, i.e. `--tags a,b --tags c` yields the three values `a`, `b`,
+// and `c`.
+//
+// A trailing `!` declares an option that must be given (e.g. `o:!`),
+// see [TParser.Validate].
+//
+// A default value may be appended after an `=` sign (e.g. `-port:=8080`);
+// it's used if the option is given neither on the commandline nor by
+// another source. Finally, the name of an environment variable may be
+// appended after a `$` sign (e.g. `-port:=8080$MYPROG_PORT`); its value
+// is used if the option is not given on the commandline. A `$` which is
+// not followed by a valid variable name at the element's end is kept as
+// part of the default value (e.g. `-price:=$5`).
+//
 // NOTE: If the given `aPattern` is empty, then the pattern `h|-help` will
 // be used which usually triggers a help request and the termination of
 // the running application.
@@ -105,8 +569,9 @@ func (eo *tExpectedOpts) parse(aPattern string) *tExpectedOpts {
 		return eo
 	}
 
-	// Reset the map to remove all previous entries
-	clear(eo.argBool)
+	// Reset the map and list to remove all previous entries
+	clear(eo.specs)
+	eo.order = eo.order[:0]
 
 	// Split the pattern string by `|` into a slice of options
 	// and their arguments:
@@ -114,55 +579,10 @@ func (eo *tExpectedOpts) parse(aPattern string) *tExpectedOpts {
 	// The order of the options reflects the order in the given
 	// pattern, but not order of options on the commandline.
 
-	oLen := len(optargs)
-	for i := 0; i < oLen; i++ {
-		opt := optargs[i]
-		if "" == opt {
-			// leading/trailing or orphaned pipe separator
-			continue
-		}
-
-		// Look for leading colons and spaces to determine whether
-		// to remove leasing garbage:
-		pos, optL := 0, len(opt)
-		for (pos < optL) && ((`:` == string(opt[pos])) || (` ` == string(opt[pos]))) {
-			pos++
-		}
-		if 0 < pos {
-			if pos == optL {
-				continue // ignore empty option
-			}
-			opt = opt[pos:]
-			optL = len(opt)
-		}
-
-		// Now, look for trailing colons and spaces to determine whether
-		// the option requires an argument:
-		pos = optL
-		needArg := false
-	argLoop:
-		for 0 < pos {
-			// for (0 < pos) && ((`:` == string(opt[pos-1])) || (` ` == string(opt[pos-1]))) {
-			switch string(opt[pos-1]) {
-			case `:`:
-				needArg = true
-				pos--
-
-			case ` `:
-				pos--
-
-			default:
-				break argLoop
-			}
-		}
-
-		if needArg || (pos < optL) {
-			if 0 == pos {
-				continue // ignore empty option
-			}
-			opt = opt[:pos]
+	for _, opt := range optargs {
+		if spec := newOptSpec(opt); nil != spec {
+			eo.add(spec)
 		}
-		eo.argBool[tOpt(opt)] = needArg
 	}
 	// Save the pattern for a possible future call:
 	eo.previous = aPattern
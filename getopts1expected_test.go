@@ -37,8 +37,8 @@ func Test_newExpectedArgs(t *testing.T) {
 	}
 } // Test_newExpectedArgs()
 
-func Test_tExpectedOpts_isValid(t *testing.T) {
-	/* used by `getopts.init()` in testing/debugging mode:
+func Test_tExpectedOpts_check(t *testing.T) {
+	/* the argument list used by `prepOptArgList()`:
 	args = []string{
 		"testingApplication",
 		`-a`, // Flag option
@@ -68,17 +68,17 @@ func Test_tExpectedOpts_isValid(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotValid := eo.isValid(tt.opt, tt.arg)
+			gotValid := (nil == eo.check(tt.opt, tt.arg))
 			if gotValid != tt.wantValid {
-				t.Errorf("%q: tExpectedOpts.isValid() gotValid = %v, want %v",
+				t.Errorf("%q: tExpectedOpts.check() gotValid = %v, want %v",
 					tt.name, gotValid, tt.wantValid)
 			}
 		})
 	}
-} // Test_tExpectedOpts_isValid()
+} // Test_tExpectedOpts_check()
 
 func Test_tExpectedArgs_parse(t *testing.T) {
-	/* used by `getopts.init()` in testing/debugging mode:
+	/* the argument list used by `prepOptArgList()`:
 	args = []string{
 		"testingApplication",
 		`-a`, // Flag option
@@ -130,4 +130,115 @@ func Test_tExpectedArgs_parse(t *testing.T) {
 	}
 } // Test_tExpectedArgs_parse()
 
+func Test_tExpectedOpts_parse_argMode(t *testing.T) {
+	eo := newExpectedOpts(`a| b: |c::| :d | e : : |-ff::|g:$MY_G|-port:=8080$MY_PORT|v=1`)
+
+	tests := []struct {
+		name string
+		opt  tOpt
+		want tArgMode
+	}{
+		{"1", "a", argNone},
+		{"2", "b", argRequired},
+		{"3", "c", argOptional},
+		{"4", "d", argNone},
+		{"5", "e", argOptional},
+		{"6", "-ff", argOptional},
+		{"7", "g", argRequired},
+		{"8", "-port", argRequired},
+		{"9", "v", argNone},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, ok := eo.specs[tt.opt]
+			if !ok {
+				t.Errorf("%q: tExpectedOpts.parse() missing option %q",
+					tt.name, tt.opt)
+				return
+			}
+			if spec.argMode != tt.want {
+				t.Errorf("%q: tExpectedOpts.parse() argMode = %d, want %d",
+					tt.name, spec.argMode, tt.want)
+			}
+		})
+	}
+} // Test_tExpectedOpts_parse_argMode()
+
+func Test_tExpectedOpts_optArgList(t *testing.T) {
+	p1 := `x|z|f:`
+	a1 := []string{`tar`, `-xzf`, `archive`}
+	w1 := tOptArgList{{"x", ""}, {"z", ""}, {"f", "archive"}}
+
+	p2 := `o:|v`
+	a2 := []string{`app`, `-ofile`, `-vofile2`}
+	w2 := tOptArgList{{"o", "file"}, {"v", ""}, {"o", "file2"}}
+
+	p3 := `help|h|e|l|p`
+	a3 := []string{`app`, `-help`, `-hel`}
+	w3 := tOptArgList{{"help", ""}, {"h", ""}, {"e", ""}, {"l", ""}}
+
+	p4 := `v|o:`
+	a4 := []string{`app`, `-vq`, `-abc`, `-o`, `-v`}
+	w4 := tOptArgList{{"v", ""}, {"q", ""}, {"abc", ""}, {"o", "-v"}}
+
+	p5 := `v|-infile:`
+	a5 := []string{`app`, `-v`, `stray`, `--infile`, `in.txt`}
+	w5 := tOptArgList{{"v", ""}, {"-infile", "in.txt"}}
+
+	p6 := `v|-output:|-verbose`
+	a6 := []string{`app`, `--output=file.txt`, `--verbose`, `stray`, `--output`, `x=y`, `--output=a=b`, `--output=`}
+	w6 := tOptArgList{{"-output", "file.txt"}, {"-verbose", ""}, {"-output", "x=y"}, {"-output", "a=b"}, {"-output", ""}}
+
+	p7 := `o::|v|-color::`
+	a7 := []string{`app`, `-o`, `stray`, `-vofile`, `--color`, `--color=auto`, `-o`}
+	w7 := tOptArgList{{"o", ""}, {"v", ""}, {"o", "file"}, {"-color", ""}, {"-color", "auto"}, {"o", ""}}
+
+	p8 := `i,-input:|v, -verbose|o,-output::`
+	a8 := []string{`app`, `--input`, `in.txt`, `-vi`, `x`, `--verbose`, `--output=o.txt`, `-ofile`}
+	w8 := tOptArgList{{"i", "in.txt"}, {"v", ""}, {"i", "x"}, {"v", ""}, {"o", "o.txt"}, {"o", "file"}}
+
+	// A required argument is taken whatever it looks like:
+	p9 := `n:|-offset:|v`
+	a9 := []string{`app`, `-n`, `-5`, `--offset`, `--`, `-v`, `-n`}
+	w9 := tOptArgList{{"n", "-5"}, {"-offset", "--"}, {"v", ""}, {"n", ""}}
+
+	// An empty commandline requests the help (once per option):
+	p10 := `-help,h|v`
+	a10 := []string{`app`}
+	w10 := tOptArgList{{"-help", ""}}
+
+	p11 := `h|-help`
+	w11 := tOptArgList{{"h", ""}, {"-help", ""}}
+
+	tests := []struct {
+		name    string
+		pattern string
+		args    []string
+		want    tOptArgList
+	}{
+		{"1", p1, a1, w1},
+		{"2", p2, a2, w2},
+		{"3", p3, a3, w3},
+		{"4", p4, a4, w4},
+		{"5", p5, a5, w5},
+		{"6", p6, a6, w6},
+		{"7", p7, a7, w7},
+		{"8", p8, a8, w8},
+		{"9", p9, a9, w9},
+		{"10", p10, a10, w10},
+		{"11", p11, a10, w11},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := newExpectedOpts(tt.pattern).optArgList(tt.args)
+			if !got.Equal(tt.want) {
+				t.Errorf("%q: tExpectedOpts.optArgList() =\n%v\n want \n%v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_tExpectedOpts_optArgList()
+
 /* _EoF_ */
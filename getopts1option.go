@@ -0,0 +1,106 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TOption` declares a single commandline option in more detail
+	// than an options pattern can do.
+	//
+	// A list of these declarations can be used instead of a pattern
+	// (see [NewParserFor]) to provide the information needed for the
+	// generated help text.
+	TOption struct {
+		// The option's pattern element, e.g. `o,-output:`, using the
+		// same syntax as a pattern for [Get] does.
+		Pattern string
+
+		// The name of the option's argument shown in the help text
+		// (e.g. `FILE`); defaults to `ARG`.
+		Arg string
+
+		// A short description of the option.
+		Description string
+
+		// The option's default value used if the option is not
+		// given at all (and shown in the help text).
+		Default string
+
+		// The environment variable providing the option's value if
+		// it's not given on the commandline (e.g. `MYPROG_OUTPUT`).
+		Env string
+
+		// Whether the option must be given (see [TParser.Validate]).
+		Required bool
+
+		// The allowed arguments of the option (e.g. `json`, `yaml`,
+		// and `text`); any other argument is reported as an error.
+		Choices []string
+
+		// Further checks of the option's arguments (see [Range] and
+		// [Matches]); a failed check is reported as an error.
+		Validators []TValidator
+
+		// The function providing the completion candidates of the
+		// option's argument at runtime (see [TCompleter]).
+		Complete TCompleter
+	}
+)
+
+// --------------------------------------------------------------------
+// tExpectedOpts constructor
+
+// `newExpectedOptsFor()` sets up an instance of `tExpectedOpts` from
+// the given option declarations.
+//
+// Parameters:
+//   - `aOptions`: The declarations of the commandline options to expect.
+//
+// Returns:
+//   - `*tExpectedOpts`: The requested `tExpectedOpts` instance.
+func newExpectedOptsFor(aOptions []TOption) *tExpectedOpts {
+	eo := &tExpectedOpts{
+		specs: make(tOptSpecs),
+	}
+
+	patterns := make([]string, 0, len(aOptions))
+	for _, o := range aOptions {
+		spec := newOptSpec(o.Pattern)
+		if nil == spec {
+			continue
+		}
+		spec.description = o.Description
+		spec.placeholder = o.Arg
+		if "" != o.Default {
+			spec.defValue = o.Default
+		}
+		if "" != o.Env {
+			spec.env = o.Env
+		}
+		if o.Required {
+			spec.required = true
+		}
+		// Copy the lists so that e.g. [TParser.AddValidator] can't
+		// change the caller's declarations:
+		spec.choices = append([]string(nil), o.Choices...)
+		spec.validators = append([]TValidator(nil), o.Validators...)
+		spec.completer = o.Complete
+		eo.add(spec)
+		patterns = append(patterns, o.Pattern)
+	}
+	eo.previous = strings.Join(patterns, `|`)
+
+	return eo
+} // newExpectedOptsFor()
+
+/* _EoF_ */
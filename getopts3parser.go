@@ -0,0 +1,504 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TParser` is a commandline parser working on its own list of
+	// commandline options and arguments.
+	//
+	// Each instance owns its options/arguments list, its list of
+	// expected options, and its iteration state. Hence several argument
+	// lists (e.g. the application's commandline and some arguments read
+	// from a wrapper script) can be processed independently of each other.
+	//
+	// A parser is not safe for concurrent use; its [TParser.Result]
+	// snapshot is.
+	TParser struct {
+		// The commandline options and arguments to process:
+		args []string
+
+		// The iterator holding the options list, the expected
+		// options, and the current iteration index:
+		iter *tIterator
+
+		// Problems found with the commandline options:
+		errs []error
+
+		// Problems found while handling the options by `Get()`
+		// (e.g. showing the help); they are kept when the options
+		// are checked again:
+		getErrs []error
+
+		// The commandline words which are neither options nor arguments:
+		operands []string
+
+		// The parser of the parent command (if any)
+		parent *TParser
+
+		// The subcommands available (for the help text)
+		commands []*TCommand
+
+		// The prefix of the environment variables automatically
+		// mapped to the options (if any)
+		envPrefix string
+
+		// The values read from a configuration file (if any)
+		config map[string][]string
+
+		// Where the options' values came from
+		sources map[tOpt]TSource
+	}
+)
+
+// --------------------------------------------------------------------
+// Helper functions
+
+// `countArgs()` returns how often an option was used according to
+// the given arguments of its occurrences.
+//
+// An argument which is an integer sets the count to that value while
+// any other argument increments it.
+//
+// Parameters:
+//   - `aArgs`: The arguments of the option's occurrences.
+//
+// Returns:
+//   - `int`: The number of times the option was given.
+func countArgs(aArgs []TArg) int {
+	result := 0
+	for _, arg := range aArgs {
+		if n, err := arg.IntE(); nil == err {
+			result = n
+		} else {
+			result++
+		}
+	}
+
+	return result
+} // countArgs()
+
+// --------------------------------------------------------------------
+// tOptSpec methods
+
+// `repeated()` returns the option's arguments to use.
+//
+// For an option declared as repeatable the arguments of all its
+// occurrences are returned, split up at commas if the option is
+// declared so. For any other option only the last argument is returned.
+//
+// Parameters:
+//   - `aArgs`: The arguments of the option's occurrences.
+//
+// Returns:
+//   - `[]TArg`: The option's arguments (empty if there are none).
+func (sp *tOptSpec) repeated(aArgs []TArg) []TArg {
+	if 0 == len(aArgs) {
+		return []TArg{}
+	}
+	if !sp.repeat {
+		return aArgs[len(aArgs)-1:]
+	}
+	if !sp.split {
+		return aArgs
+	}
+
+	result := make([]TArg, 0, len(aArgs))
+	for _, arg := range aArgs {
+		for _, part := range strings.Split(string(arg), `,`) {
+			if "" != part {
+				result = append(result, TArg(part))
+			}
+		}
+	}
+
+	return result
+} // repeated()
+
+// --------------------------------------------------------------------
+// TParser constructor
+
+// `NewParser()` returns a new parser for the given argument list.
+//
+// The first element of `aArgList` is expected to be the application's
+// name (like in `os.Args`) and is ignored. If `aPattern` is empty, the
+// pattern `h|-help` will be used.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//   - `aPattern`: The pattern declaring which commandline options to expect.
+//
+// Returns:
+//   - `*TParser`: The new parser instance.
+func NewParser(aArgList []string, aPattern string) *TParser {
+	p := &TParser{
+		args: append([]string(nil), aArgList...),
+		iter: newIterator(&tOptArgList{}),
+	}
+
+	return p.setPattern(aPattern)
+} // NewParser()
+
+// `NewParserFor()` returns a new parser for the given argument list
+// expecting the declared options.
+//
+// Other than [NewParser] this constructor accepts a list of detailed
+// option declarations which allows e.g. generating a help text (see
+// [TParser.Usage]).
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//   - `aOptions`: The declarations of the commandline options to expect.
+//
+// Returns:
+//   - `*TParser`: The new parser instance.
+func NewParserFor(aArgList []string, aOptions []TOption) *TParser {
+	return newParser(aArgList, newExpectedOptsFor(aOptions))
+} // NewParserFor()
+
+// `newParser()` returns a new parser for the given argument list
+// expecting the given options.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments.
+//   - `aExpected`: The options to expect.
+//
+// Returns:
+//   - `*TParser`: The new parser instance.
+func newParser(aArgList []string, aExpected *tExpectedOpts) *TParser {
+	p := &TParser{
+		args: append([]string(nil), aArgList...),
+		iter: newIterator(&tOptArgList{}),
+	}
+
+	return p.setExpected(aExpected)
+} // newParser()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `Count()` returns how often the given option was used.
+//
+// This is meant for flag options like `-v` where e.g. `-vvv` or
+// `-v -v -v` indicate a verbosity level of `3`. For a repeatable flag
+// (e.g. `v,-verbose*`) an occurrence with a numeric argument (e.g.
+// `--verbose=2`) sets the count to that value while any other
+// occurrence increments it.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `int`: The number of times the option was given.
+func (p *TParser) Count(aOpt string) int {
+	return countArgs(p.values(tOpt(aOpt)))
+} // Count()
+
+// `Err()` returns all problems found with the commandline options.
+//
+// Returns:
+//   - `error`: `nil` if there are no problems, or all errors joined.
+func (p *TParser) Err() error {
+	return errors.Join(p.Errors()...)
+} // Err()
+
+// `Errors()` returns the list of problems found with the commandline
+// options in the order of their appearance.
+//
+// Each element is of type [ErrUnknownOption], [ErrMissingArgument],
+// [ErrUnexpectedArgument], or [ErrInvalidArgument] which can be
+// examined using `errors.As()`.
+// They are followed by the problems found by [TParser.Get] (e.g. a
+// failing help shower wrapped by [ErrHelpRequested]).
+//
+// Returns:
+//   - `[]error`: The list of errors (empty if there are none).
+func (p *TParser) Errors() []error {
+	result := make([]error, 0, len(p.errs)+len(p.getErrs))
+	result = append(result, p.errs...)
+	result = append(result, p.getErrs...)
+
+	return result
+} // Errors()
+
+// `Get()` retrieves the next commandline option and its argument.
+//
+// Options which are not declared in the pattern or are missing their
+// required argument are skipped; they can be examined by [TParser.Errors].
+// Options not given on the commandline but set by the environment, a
+// configuration file, or their default value follow after the ones
+// given on the commandline.
+//
+// If the retrieved option is `-h` or `--help` and a global [HelpShower]
+// is set up, its `ShowHelp()` method is called. An error returned by that
+// method aborts the processing: it is logged and [ExitFunc] is called to
+// terminate the running application. If [ExitFunc] is `nil` (or returns
+// at all) the error – wrapped by [ErrHelpRequested] – is added to the
+// parser's errors and `rMore` is `false`.
+//
+// The hidden option `--generate-completion <shell>` is handled likewise:
+// the requested completion script (see [TParser.Completion]) is written
+// to [CompletionWriter] and [ExitFunc] is called with the exit code `0`.
+// If [ExitFunc] is `nil` (or returns at all) `Get()` returns `?` and
+// `rMore` is `false`, i.e. the hidden option is never returned itself.
+// If the commandline's first word is the hidden operand `__complete`
+// and an option declares a [TCompleter], the completion candidates for
+// the following words are written instead.
+//
+// Returns:
+//   - `rOpt`: The current option in the iteration.
+//   - `rArg`: The option's argument in the iteration.
+//   - `rMore`: Indicator for whether there are more options to come.
+func (p *TParser) Get() (rOpt string, rArg TArg, rMore bool) {
+	if p.isCompleting() {
+		if err := showCandidates(p.candidates(p.args[2:])); nil != err {
+			p.getErrs = append(p.getErrs, err)
+		}
+		// Abort the processing:
+		p.iter.index = len(*p.iter.optArgs)
+		return `?`, TArg(""), false
+	}
+
+	o, rArg, rMore := p.iter.Next()
+	if `` == o {
+		// This might happen if the last commandline option is
+		// invalid (i.e. not defined in the pattern or missing
+		// its required argument).
+		rOpt = string(`?`)
+	} else {
+		if spec, ok := p.iter.expected.specs[o]; ok && spec.isHelp() && (nil != HelpShower) {
+			if err := helpShowerFor(p).ShowHelp(); nil != err {
+				p.getErrs = append(p.getErrs, fmt.Errorf("%w: %w", ErrHelpRequested, err))
+				if nil != ExitFunc {
+					log.Println(err.Error())
+					// Perhaps somebody needs time?
+					runtime.Gosched()
+					// And here we go ...
+					ExitFunc(1)
+				}
+
+				// Abort the processing:
+				p.iter.index = len(*p.iter.optArgs)
+				rMore = false
+			}
+		} else if completionOpt == o {
+			if err := p.showCompletion(string(rArg)); nil != err {
+				p.getErrs = append(p.getErrs, err)
+			}
+			// Abort the processing (the hidden option is
+			// handled here, not by the caller):
+			p.iter.index = len(*p.iter.optArgs)
+			return `?`, TArg(""), false
+		}
+		rOpt = string(o)
+	}
+
+	return
+} // Get()
+
+// `IsHelp()` returns whether a help option (`-h` or `--help` or one
+// of their aliases) was given on the commandline.
+//
+// This allows the caller to decide how to handle a help request, e.g.
+// by showing the text returned by [TParser.Usage] and terminating the
+// application in an orderly way.
+//
+// Returns:
+//   - `bool`: Indicator for whether help was requested.
+func (p *TParser) IsHelp() bool {
+	eo := p.iter.expected
+	for _, oa := range *p.iter.optArgs {
+		if spec, ok := eo.specs[oa.opt]; ok && spec.isHelp() && (nil == eo.check(oa.opt, oa.arg)) {
+			return true
+		}
+	}
+
+	return false
+} // IsHelp()
+
+// `Lookup()` returns the argument of the given option.
+//
+// If the option was given several times on the commandline, the
+// argument of its last occurrence is returned. If the option is not
+// given on the commandline, its value is taken from the environment,
+// a configuration file, or its default value (in that order); see
+// [TParser.Source].
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `TArg`: The option's argument.
+//   - `bool`: Indicator for whether the option has a value.
+func (p *TParser) Lookup(aOpt string) (TArg, bool) {
+	args := p.values(tOpt(aOpt))
+	if 0 == len(args) {
+		return TArg(""), false
+	}
+
+	return args[len(args)-1], true
+} // Lookup()
+
+// `Operands()` returns the positional arguments of the commandline.
+//
+// These are all words which are neither options nor their arguments,
+// given in their original order. This includes the words interleaved
+// with the options as well as all words following the end-of-options
+// marker `--`.
+//
+// Returns:
+//   - `[]string`: The list of operands (empty if there are none).
+func (p *TParser) Operands() []string {
+	result := make([]string, len(p.operands))
+	copy(result, p.operands)
+
+	return result
+} // Operands()
+
+// `Parent()` returns the parser of the parent command.
+//
+// When using a tree of [TCommand]s the options given before the name
+// of a subcommand are processed by the parent command's parser which
+// is available to the subcommand's handler by this method.
+//
+// Returns:
+//   - `*TParser`: The parent parser or `nil` if there's none.
+func (p *TParser) Parent() *TParser {
+	return p.parent
+} // Parent()
+
+// `Reset()` resets the parser's iteration to the first option.
+func (p *TParser) Reset() {
+	p.iter.Reset()
+} // Reset()
+
+// `values()` returns the arguments of all valid occurrences of the
+// given option in their commandline order.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases).
+//
+// Returns:
+//   - `[]TArg`: The list of arguments.
+func (p *TParser) values(aOpt tOpt) []TArg {
+	eo := p.iter.expected
+	aOpt = eo.canonical(aOpt)
+
+	var result []TArg
+	for _, oa := range *p.iter.optArgs {
+		if (aOpt == oa.opt) && (nil == eo.check(oa.opt, oa.arg)) {
+			result = append(result, oa.arg)
+		}
+	}
+
+	return result
+} // values()
+
+// `Values()` returns the arguments of the given option.
+//
+// For an option declared as repeatable (e.g. `I:*`) the arguments of all
+// its occurrences are returned in their commandline order, split up at
+// commas if the option is declared so (e.g. `-tags:*,`). For any other
+// option only the argument of its last occurrence is returned.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `[]TArg`: The option's arguments (empty if the option wasn't given).
+func (p *TParser) Values(aOpt string) []TArg {
+	spec, ok := p.iter.expected.specs[tOpt(aOpt)]
+	if !ok {
+		return []TArg{}
+	}
+
+	return spec.repeated(p.values(tOpt(aOpt)))
+} // Values()
+
+// `setExpected()` sets up the expected options for the parser.
+//
+// The iteration starts anew with the first option and the commandline
+// options are checked again.
+//
+// Parameters:
+//   - `aExpected`: The options to expect.
+//
+// Returns:
+//   - `*TParser`: The parser instance with the updated options.
+func (p *TParser) setExpected(aExpected *tExpectedOpts) *TParser {
+	aExpected.addCompletion()
+	p.iter.expected = aExpected
+	p.iter.Reset()
+
+	// The options decide how to interpret the commandline:
+	p.iter.optArgs, p.operands = aExpected.optArgList(p.args)
+
+	// Record the options given on the commandline:
+	p.sources = make(map[tOpt]TSource, len(*p.iter.optArgs))
+	for _, oa := range *p.iter.optArgs {
+		if _, known := aExpected.specs[oa.opt]; known {
+			p.sources[aExpected.canonical(oa.opt)] = SourceCommandline
+		}
+	}
+
+	// Options not given may be set by the environment,
+	// by a configuration file, or else by their default:
+	p.addEnv()
+	p.addConfig()
+	p.addDefaults()
+
+	// Collect the problems with the options:
+	p.errs = nil
+	if p.isCompleting() {
+		// A partial commandline isn't checked:
+		return p
+	}
+	for _, oa := range *p.iter.optArgs {
+		if err := aExpected.check(oa.opt, oa.arg); nil != err {
+			p.errs = append(p.errs, err)
+		} else if err = aExpected.specs[oa.opt].validate(oa.arg); nil != err {
+			p.errs = append(p.errs, err)
+		}
+	}
+
+	return p
+} // setExpected()
+
+// `setPattern()` sets up the options pattern for the parser.
+//
+// If `aPattern` differs from the previously used one, the iteration
+// starts anew with the first option and the commandline options are
+// checked again.
+//
+// Parameters:
+//   - `aPattern`: The pattern declaring which commandline options to expect.
+//
+// Returns:
+//   - `*TParser`: The parser instance with the updated pattern.
+func (p *TParser) setPattern(aPattern string) *TParser {
+	if "" == aPattern {
+		aPattern = "h|-help"
+	}
+	if (nil != p.iter.expected) && (p.iter.expected.previous == aPattern) {
+		// Nothing changed, hence no need to check the options again
+		return p
+	}
+
+	return p.setExpected(newExpectedOpts(aPattern))
+} // setPattern()
+
+/* _EoF_ */
@@ -0,0 +1,377 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestNewParser(t *testing.T) {
+	a1 := []string{
+		`appname`,
+		`-a`,
+		`--infile`, `config.in`,
+	}
+	p1 := "a|-infile:"
+	w1 := `["a": ""]
+["-infile": "config.in"]
+`
+	a2 := []string{`appname`}
+	p2 := ""
+	w2 := `["h": ""]
+["-help": ""]
+`
+
+	tests := []struct {
+		name    string
+		args    []string
+		pattern string
+		want    string
+	}{
+		{"1", a1, p1, w1},
+		{"2", a2, p2, w2},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewParser(tt.args, tt.pattern)
+			if nil == got {
+				t.Errorf("%q: NewParser() = %v, want %v",
+					tt.name, nil, "not NIL")
+				return
+			}
+			if gotStr := got.iter.optArgs.String(); gotStr != tt.want {
+				t.Errorf("%q: NewParser() =\n%s\n want \n%s",
+					tt.name, gotStr, tt.want)
+			}
+		})
+	}
+} // TestNewParser()
+
+func TestTParser_Get(t *testing.T) {
+	// Two parsers with different argument lists must not interfere
+	// with each other (nor with the global default parser):
+	p1 := NewParser([]string{
+		`appname`,
+		`-a`,
+		`--infile`, `config.in`,
+		`-i`, // Error: missing argument => skipped
+	}, "a|i:|-infile:")
+	p2 := NewParser([]string{
+		`wrapper`,
+		`-v`,
+		`--output`, `out.txt`,
+	}, "v|-output:")
+
+	tests := []struct {
+		name     string
+		parser   *TParser
+		wantOpt  string
+		wantArg  TArg
+		wantMore bool
+	}{
+		{"1", p1, "a", "", true},
+		{"2", p2, "v", "", true},
+		{"3", p1, "-infile", "config.in", true},
+		{"4", p2, "-output", "out.txt", false},
+		{"5", p1, "?", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOpt, gotArg, gotMore := tt.parser.Get()
+			if gotOpt != tt.wantOpt {
+				t.Errorf("%q: TParser.Get() gotOpt = %q, want %q",
+					tt.name, gotOpt, tt.wantOpt)
+			}
+			if gotArg != tt.wantArg {
+				t.Errorf("%q: TParser.Get() gotArg = %q, want %q",
+					tt.name, gotArg, tt.wantArg)
+			}
+			if gotMore != tt.wantMore {
+				t.Errorf("%q: TParser.Get() gotMore = %t, want %t",
+					tt.name, gotMore, tt.wantMore)
+			}
+		})
+	}
+} // TestTParser_Get()
+
+func TestTParser_Errors(t *testing.T) {
+	a1 := []string{
+		`appname`,
+		`-a`,
+		`--outfile`, `out.txt`, // Error: unknown option
+		`--output`, `out.txt`,
+		`-i`, // Error: missing argument
+	}
+	p1 := "a|i:|-output:"
+	w1 := []error{
+		ErrUnknownOption{Opt: "-outfile"},
+		ErrMissingArgument{Opt: "i"},
+	}
+	p2 := "a|i|-outfile:|-output:"
+
+	// A flag doesn't accept an argument:
+	a4 := []string{`appname`, `--quiet=yes`, `--help=x`, `-v`}
+	p4 := "q,-quiet|h,-help|v"
+	w4 := []error{
+		ErrUnexpectedArgument{Opt: "-quiet", Arg: "yes"},
+		ErrUnexpectedArgument{Opt: "-help", Arg: "x"},
+	}
+
+	// The errors name the options as given, not by their canonical name:
+	a3 := []string{`appname`, `--in`, `--input`}
+	p3 := "i,-input:"
+	w3 := []error{
+		ErrUnknownOption{Opt: "-in"},
+		ErrMissingArgument{Opt: "-input"},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		pattern string
+		want    []error
+	}{
+		{"1", a1, p1, w1},
+		{"2", a1, p2, nil},
+		{"3", a3, p3, w3},
+		{"4", a4, p4, w4},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.args, tt.pattern)
+			got := p.Errors()
+			if len(got) != len(tt.want) {
+				t.Errorf("%q: TParser.Errors() = %v, want %v",
+					tt.name, got, tt.want)
+				return
+			}
+			for idx, err := range got {
+				if err != tt.want[idx] {
+					t.Errorf("%q: TParser.Errors()[%d] = %v, want %v",
+						tt.name, idx, err, tt.want[idx])
+				}
+			}
+			if (0 == len(tt.want)) != (nil == p.Err()) {
+				t.Errorf("%q: TParser.Err() = %v, want %v",
+					tt.name, p.Err(), tt.want)
+			}
+		})
+	}
+} // TestTParser_Errors()
+
+func TestTParser_Operands(t *testing.T) {
+	p1 := "v|o:"
+	a1 := []string{`myprog`, `-v`, `a.txt`, `b.txt`}
+	w1 := []string{`a.txt`, `b.txt`}
+
+	a2 := []string{`myprog`, `a.txt`, `-o`, `out.txt`, `-`, `-v`, `b.txt`}
+	w2 := []string{`a.txt`, `-`, `b.txt`}
+
+	a3 := []string{`myprog`, `-v`, `--`, `-o`, `--`, `c.txt`}
+	w3 := []string{`-o`, `--`, `c.txt`}
+
+	a4 := []string{`myprog`, `-o`, `out.txt`}
+	w4 := []string{}
+
+	tests := []struct {
+		name    string
+		args    []string
+		pattern string
+		want    []string
+	}{
+		{"1", a1, p1, w1},
+		{"2", a2, p1, w2},
+		{"3", a3, p1, w3},
+		{"4", a4, p1, w4},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewParser(tt.args, tt.pattern).Operands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TParser.Operands() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_Operands()
+
+func TestTParser_Values(t *testing.T) {
+	args := []string{
+		`app`,
+		`-I`, `inc1`,
+		`--include`, `inc2`,
+		`-Iinc3`,
+		`--tags`, `a,b`, `--tags=c`,
+		`-o`, `first`, `-o`, `last`,
+	}
+	p := NewParser(args, "I,-include:*|-tags:+,|o:|v")
+
+	tests := []struct {
+		name string
+		opt  string
+		want []TArg
+	}{
+		{"1", "I", []TArg{"inc1", "inc2", "inc3"}},
+		{"2", "-include", []TArg{"inc1", "inc2", "inc3"}},
+		{"3", "-tags", []TArg{"a", "b", "c"}},
+		{"4", "o", []TArg{"last"}},
+		{"5", "v", []TArg{}},
+		{"6", "x", []TArg{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Values(tt.opt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TParser.Values() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_Values()
+
+func TestTParser_Lookup(t *testing.T) {
+	p := NewParser([]string{`app`, `-o`, `first`, `--output=last`, `-v`}, "o,-output:|v|q")
+
+	tests := []struct {
+		name   string
+		opt    string
+		want   TArg
+		wantOK bool
+	}{
+		{"1", "o", "last", true},
+		{"2", "-output", "last", true},
+		{"3", "v", "", true},
+		{"4", "q", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := p.Lookup(tt.opt)
+			if (got != tt.want) || (gotOK != tt.wantOK) {
+				t.Errorf("%q: TParser.Lookup() = %q, %v, want %q, %v",
+					tt.name, got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+} // TestTParser_Lookup()
+
+func TestTParser_Count(t *testing.T) {
+	pattern := "v,-verbose*|q|o:"
+
+	tests := []struct {
+		name string
+		args []string
+		opt  string
+		want int
+	}{
+		{"1", []string{`app`, `-vvv`}, "v", 3},
+		{"2", []string{`app`, `-v`, `-v`, `--verbose`}, "v", 3},
+		{"3", []string{`app`, `-vqv`, `-ofile`}, "-verbose", 2},
+		{"4", []string{`app`, `--verbose=5`}, "v", 5},
+		{"5", []string{`app`, `--verbose=2`, `-v`}, "v", 3},
+		{"6", []string{`app`, `-o`, `x`}, "v", 0},
+		{"7", []string{`app`, `--q=2`}, "q", 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewParser(tt.args, pattern).Count(tt.opt); got != tt.want {
+				t.Errorf("%q: TParser.Count() = %d, want %d",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_Count()
+
+type tFailingHelp struct {
+	calls int
+}
+
+func (fh *tFailingHelp) ShowHelp() error {
+	fh.calls++
+	return errors.New("help shown")
+} // ShowHelp()
+
+func TestTParser_Get_help(t *testing.T) {
+	fh := &tFailingHelp{}
+	exitCode := -1
+	oldShower, oldExit := HelpShower, ExitFunc
+	HelpShower = fh
+	ExitFunc = func(aCode int) { exitCode = aCode }
+	defer func() {
+		HelpShower, ExitFunc = oldShower, oldExit
+	}()
+
+	p := NewParser([]string{`app`, `-a`, `--help`, `-b`}, "a|b|h,-help")
+	tests := []struct {
+		name     string
+		wantOpt  string
+		wantMore bool
+	}{
+		{"1", "a", true},
+		{"2", "h", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOpt, _, gotMore := p.Get()
+			if (gotOpt != tt.wantOpt) || (gotMore != tt.wantMore) {
+				t.Errorf("%q: TParser.Get() = %q, %t, want %q, %t",
+					tt.name, gotOpt, gotMore, tt.wantOpt, tt.wantMore)
+			}
+		})
+	}
+	if 1 != fh.calls {
+		t.Errorf("ShowHelp() called %d times, want %d", fh.calls, 1)
+	}
+	if 1 != exitCode {
+		t.Errorf("ExitFunc() code = %d, want %d", exitCode, 1)
+	}
+	if !errors.Is(p.Err(), ErrHelpRequested) {
+		t.Errorf("TParser.Err() = %v, want %v", p.Err(), ErrHelpRequested)
+	}
+	// Checking the options again keeps the help error:
+	p.AddValidator("a", Matches(`^$`))
+	if !errors.Is(p.Err(), ErrHelpRequested) {
+		t.Errorf("TParser.AddValidator() Err() = %v, want %v", p.Err(), ErrHelpRequested)
+	}
+} // TestTParser_Get_help()
+
+func TestTParser_IsHelp(t *testing.T) {
+	pattern := "a|h,-help|-hilfe"
+
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"1", []string{`app`, `-a`}, false},
+		{"2", []string{`app`, `-a`, `-h`}, true},
+		{"3", []string{`app`, `--help`}, true},
+		{"4", []string{`app`, `--hilfe`}, false},
+		{"5", []string{`app`}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewParser(tt.args, pattern).IsHelp(); got != tt.want {
+				t.Errorf("%q: TParser.IsHelp() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_IsHelp()
+
+/* _EoF_ */
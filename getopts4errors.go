@@ -0,0 +1,255 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrHelpRequested` is reported if a help option was given and the
+	// `HelpShower.ShowHelp()` method returned an error while [ExitFunc]
+	// did not terminate the application.
+	ErrHelpRequested = errors.New("getopts: help requested")
+)
+
+type (
+	// `ErrUnknownOption` is reported for a commandline option that
+	// is not declared in the options pattern.
+	//
+	// This corresponds to the `\?` case of the shell's `getopts`.
+	ErrUnknownOption struct {
+		// The option's name as given on the commandline (even if
+		// it's an alias), i.e. without its first leading hyphen.
+		Opt string
+	}
+
+	// `ErrMissingArgument` is reported for a commandline option that
+	// requires an argument which was not given.
+	//
+	// This corresponds to the `:` case of the shell's `getopts`.
+	ErrMissingArgument struct {
+		// The option's name as given on the commandline (even if
+		// it's an alias), i.e. without its first leading hyphen.
+		Opt string
+	}
+
+	// `ErrUnexpectedArgument` is reported for a flag option given
+	// with an attached argument (e.g. `--quiet=yes`) which it doesn't
+	// accept.
+	ErrUnexpectedArgument struct {
+		// The option's name as given on the commandline (even if
+		// it's an alias), i.e. without its first leading hyphen.
+		Opt string
+
+		// The rejected argument.
+		Arg string
+	}
+
+	// `ErrRequiredOption` is reported for an option which is declared
+	// to be required but was not given on the commandline.
+	ErrRequiredOption struct {
+		// The option's canonical name, i.e. without its first
+		// leading hyphen.
+		Opt string
+	}
+
+	// `ErrExclusiveOptions` is reported if several options of a group
+	// of mutually exclusive options were given (see [Exclusive]).
+	ErrExclusiveOptions struct {
+		// The canonical names of the options given.
+		Opts []string
+	}
+
+	// `ErrDependentOption` is reported if an option was given without
+	// another option it depends on (see [Requires]).
+	ErrDependentOption struct {
+		// The canonical name of the option given.
+		Opt string
+
+		// The canonical name of the missing option.
+		Requires string
+	}
+
+	// `ErrOneOfOptions` is reported if none of a group of options
+	// was given while at least one of them is required (see [OneOf]).
+	ErrOneOfOptions struct {
+		// The canonical names of the options of the group.
+		Opts []string
+	}
+
+	// `ErrInvalidArgument` is reported if an option's argument is
+	// not one of its declared choices or fails one of its validators.
+	ErrInvalidArgument struct {
+		// The option's canonical name.
+		Opt string
+
+		// The rejected argument.
+		Arg string
+
+		// The reason of the rejection.
+		Err error
+	}
+
+	// `ErrUnknownCommand` is reported if the name of a subcommand
+	// is not known (or missing at all).
+	ErrUnknownCommand struct {
+		// The unknown command's name (empty if it's missing).
+		Cmd string
+	}
+
+	// `ErrInvalidValue` is reported if an option's argument can't be
+	// converted to the requested type.
+	ErrInvalidValue struct {
+		// The argument that failed to convert
+		Arg string
+
+		// The requested kind of value (e.g. "integer")
+		Kind string
+
+		// The underlying conversion error (if any)
+		Err error
+	}
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrUnknownOption) Error() string {
+	return fmt.Sprintf("getopts: unknown option %q", tOpt(e.Opt).flag())
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrMissingArgument) Error() string {
+	return fmt.Sprintf("getopts: option %q requires an argument", tOpt(e.Opt).flag())
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrUnexpectedArgument) Error() string {
+	return fmt.Sprintf("getopts: option %q doesn't allow an argument", tOpt(e.Opt).flag())
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrRequiredOption) Error() string {
+	return fmt.Sprintf("getopts: required option %q is missing", tOpt(e.Opt).flag())
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrExclusiveOptions) Error() string {
+	return fmt.Sprintf("getopts: options %s are mutually exclusive", flagList(e.Opts))
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrDependentOption) Error() string {
+	return fmt.Sprintf("getopts: option %q requires option %q",
+		tOpt(e.Opt).flag(), tOpt(e.Requires).flag())
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrOneOfOptions) Error() string {
+	return fmt.Sprintf("getopts: one of the options %s is required", flagList(e.Opts))
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrInvalidArgument) Error() string {
+	return fmt.Sprintf("getopts: invalid argument %q of option %q: %v",
+		e.Arg, tOpt(e.Opt).flag(), e.Err)
+} // Error()
+
+// `Unwrap()` returns the reason of the rejection.
+//
+// Returns:
+//   - `error`: The underlying error.
+func (e ErrInvalidArgument) Unwrap() error {
+	return e.Err
+} // Unwrap()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrUnknownCommand) Error() string {
+	if "" == e.Cmd {
+		return "getopts: missing command"
+	}
+
+	return fmt.Sprintf("getopts: unknown command %q", e.Cmd)
+} // Error()
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+//   - `string`: The error's description.
+func (e ErrInvalidValue) Error() string {
+	if nil != e.Err {
+		var ne *strconv.NumError
+		if errors.As(e.Err, &ne) {
+			// Avoid repeating the argument and function name:
+			return fmt.Sprintf("getopts: invalid %s value %q: %v", e.Kind, e.Arg, ne.Err)
+		}
+		return fmt.Sprintf("getopts: invalid %s value %q: %v", e.Kind, e.Arg, e.Err)
+	}
+
+	return fmt.Sprintf("getopts: invalid %s value %q", e.Kind, e.Arg)
+} // Error()
+
+// `Unwrap()` returns the underlying conversion error (if any).
+//
+// Returns:
+//   - `error`: The underlying error.
+func (e ErrInvalidValue) Unwrap() error {
+	return e.Err
+} // Unwrap()
+
+// --------------------------------------------------------------------
+
+// `flagList()` returns the given options as a comma separated list
+// of quoted commandline flags.
+//
+// Parameters:
+//   - `aOpts`: The options' names without their first leading hyphen.
+//
+// Returns:
+//   - `string`: The list of flags, e.g. `"-q", "--verbose"`.
+func flagList(aOpts []string) string {
+	flags := make([]string, 0, len(aOpts))
+	for _, opt := range aOpts {
+		flags = append(flags, strconv.Quote(tOpt(opt).flag()))
+	}
+
+	return strings.Join(flags, ", ")
+} // flagList()
+
+/* _EoF_ */
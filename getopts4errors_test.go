@@ -0,0 +1,54 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestErrUnknownOption_Error(t *testing.T) {
+	var ue ErrUnknownOption
+	err := NewParser([]string{`app`, `--outfile`, `x`}, "-output:").Err()
+	if !errors.As(err, &ue) {
+		t.Fatalf("TParser.Err() = %v, want %T", err, ue)
+	}
+
+	_, ieErr := TArg("abc").IntE()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"1", ue, `getopts: unknown option "--outfile"`},
+		{"2", ErrMissingArgument{Opt: "o"}, `getopts: option "-o" requires an argument`},
+		{"3", ErrRequiredOption{Opt: "-output"}, `getopts: required option "--output" is missing`},
+		{"4", ErrInvalidValue{Arg: "abc", Kind: "boolean"}, `getopts: invalid boolean value "abc"`},
+		{"5", ieErr, `getopts: invalid integer value "abc": invalid syntax`},
+		{"6", ErrUnknownCommand{Cmd: "list"}, `getopts: unknown command "list"`},
+		{"7", ErrUnknownCommand{}, `getopts: missing command`},
+		{"8", ErrExclusiveOptions{Opts: []string{"q", "-verbose"}}, `getopts: options "-q", "--verbose" are mutually exclusive`},
+		{"9", ErrDependentOption{Opt: "-key", Requires: "-cert"}, `getopts: option "--key" requires option "--cert"`},
+		{"10", ErrOneOfOptions{Opts: []string{"-file", "-url"}}, `getopts: one of the options "--file", "--url" is required`},
+		{"11", ErrUnexpectedArgument{Opt: "-quiet", Arg: "yes"}, `getopts: option "--quiet" doesn't allow an argument`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("%q: Error() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestErrUnknownOption_Error()
+
+/* _EoF_ */
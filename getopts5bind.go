@@ -0,0 +1,428 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tBinding` connects a struct field with a commandline option.
+	tBinding struct {
+		// The field to set
+		field reflect.Value
+
+		// The option's canonical name
+		opt tOpt
+
+		// The field's pattern element (e.g. `o,-output:`)
+		pattern string
+
+		// Whether the option must be given
+		required bool
+
+		// Whether the flag's occurrences are counted
+		count bool
+	}
+
+	// `tBindings` is the list of bindings of a config struct.
+	tBindings struct {
+		// The option bindings in the order of the struct fields
+		list []*tBinding
+
+		// A field to receive the commandline operands (if any)
+		operands reflect.Value
+	}
+)
+
+const (
+	// The struct tag's key
+	bindTag = "getopts"
+)
+
+var (
+	// Types which are handled specially (instead of by their kind):
+	gDurationType = reflect.TypeOf(time.Duration(0))
+	gTimeType     = reflect.TypeOf(time.Time{})
+	gURLType      = reflect.TypeOf((*url.URL)(nil))
+)
+
+// --------------------------------------------------------------------
+// tBindings constructor
+
+// `newBindings()` reflects over the fields of `aConfig` looking for
+// `getopts` struct tags.
+//
+// A tag consists of a comma separated list of the option's names (as
+// used in a pattern, i.e. without the first leading hyphen) and
+// optional keywords:
+//
+//   - `required`: the option must be given on the commandline;
+//   - `split`: a slice field's arguments are split up at commas;
+//   - `count`: the (integer) field receives the flag's number of uses;
+//   - `operands`: the (`[]string`) field receives the operands;
+//   - `$NAME`: the environment variable `NAME` provides a fallback value.
+//
+// Parameters:
+//   - `aConfig`: A pointer to the struct to fill.
+//
+// Returns:
+//   - `*tBindings`: The list of bindings found.
+//   - `error`: A possible error during processing.
+func newBindings(aConfig any) (*tBindings, error) {
+	rv := reflect.ValueOf(aConfig)
+	if (reflect.Pointer != rv.Kind()) || rv.IsNil() || (reflect.Struct != rv.Elem().Kind()) {
+		return nil, fmt.Errorf("getopts: expected a pointer to a struct, got %T", aConfig)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	result := &tBindings{}
+	for idx := 0; idx < rt.NumField(); idx++ {
+		sf := rt.Field(idx)
+		tag, ok := sf.Tag.Lookup(bindTag)
+		if !ok || !sf.IsExported() {
+			continue
+		}
+
+		b := &tBinding{
+			field: rv.Field(idx),
+		}
+		var (
+			env, names      []string
+			operands, split bool
+		)
+		for _, word := range strings.Split(tag, `,`) {
+			switch word = strings.TrimSpace(word); word {
+			case "":
+				// ignore empty entries
+			case "required":
+				b.required = true
+			case "operands":
+				operands = true
+			case "split":
+				split = true
+			case "count":
+				b.count = true
+			default:
+				if strings.HasPrefix(word, `$`) {
+					env = append(env, word)
+					continue
+				}
+				names = append(names, word)
+			}
+		}
+
+		if operands {
+			if reflect.TypeOf([]string(nil)) != sf.Type {
+				return nil, fmt.Errorf("getopts: operands field %q must be of type []string", sf.Name)
+			}
+			result.operands = b.field
+			continue
+		}
+		if 0 == len(names) {
+			return nil, fmt.Errorf("getopts: no option name given for field %q", sf.Name)
+		}
+		b.opt = tOpt(names[0])
+
+		marker := ""
+		if b.required {
+			marker = `!`
+		}
+
+		if b.count {
+			if (reflect.Int > sf.Type.Kind()) || (reflect.Int64 < sf.Type.Kind()) {
+				return nil, fmt.Errorf("getopts: count field %q must be an integer", sf.Name)
+			}
+			b.pattern = strings.Join(names, `,`) + marker + strings.Join(env, ``)
+			result.list = append(result.list, b)
+			continue
+		}
+
+		ft, suffix := sf.Type, ""
+		if reflect.Slice == ft.Kind() {
+			// A repeatable option collecting all its arguments
+			ft, suffix = ft.Elem(), `*`
+			if split {
+				suffix += `,`
+			}
+		}
+		colons, ok := argColons(ft)
+		if !ok || (("" != suffix) && ("" == colons)) {
+			return nil, fmt.Errorf("getopts: unsupported type %s of field %q", sf.Type, sf.Name)
+		}
+		b.pattern = strings.Join(names, `,`) + colons + suffix + marker + strings.Join(env, ``)
+		result.list = append(result.list, b)
+	}
+
+	return result, nil
+} // newBindings()
+
+// `argColons()` returns the colons to use in a pattern for an option
+// bound to a field of the given type.
+//
+// Parameters:
+//   - `aType`: The type of the field to bind.
+//
+// Returns:
+//   - `string`: Empty for a flag option or `:` for a required argument.
+//   - `bool`: Indicator for whether the type is supported.
+func argColons(aType reflect.Type) (string, bool) {
+	switch kind := aType.Kind(); {
+	case reflect.Bool == kind:
+		// flag option
+		return "", true
+
+	case (gDurationType == aType) || (gTimeType == aType) || (gURLType == aType):
+		return `:`, true
+
+	case (reflect.Int <= kind && reflect.Float64 >= kind && reflect.Uintptr != kind) || (reflect.String == kind):
+		// all integer and float kinds as well as strings
+		return `:`, true
+	}
+
+	return "", false
+} // argColons()
+
+// --------------------------------------------------------------------
+// tBinding methods
+
+// `set()` assigns the given arguments to the bound field.
+//
+// A slice field gets all arguments while any other field is set to
+// the last one.
+//
+// Parameters:
+//   - `aArgs`: The option's arguments to assign.
+//
+// Returns:
+//   - `error`: A possible conversion error.
+func (b *tBinding) set(aArgs []TArg) error {
+	if 0 == len(aArgs) {
+		return nil
+	}
+	if reflect.Slice != b.field.Kind() {
+		return setField(b.field, aArgs[len(aArgs)-1])
+	}
+
+	list := reflect.MakeSlice(b.field.Type(), len(aArgs), len(aArgs))
+	for idx, arg := range aArgs {
+		if err := setField(list.Index(idx), arg); nil != err {
+			return err
+		}
+	}
+	b.field.Set(list)
+
+	return nil
+} // set()
+
+// `setField()` assigns the given argument to a field.
+//
+// Parameters:
+//   - `aField`: The field to set.
+//   - `aArg`: The option's argument to assign.
+//
+// Returns:
+//   - `error`: A possible conversion error.
+func setField(aField reflect.Value, aArg TArg) error {
+	switch aField.Type() {
+	case gDurationType:
+		v, err := aArg.DurationE()
+		if nil != err {
+			return err
+		}
+		aField.SetInt(int64(v))
+		return nil
+
+	case gTimeType:
+		v, err := aArg.TimeE()
+		if nil != err {
+			return err
+		}
+		aField.Set(reflect.ValueOf(v))
+		return nil
+
+	case gURLType:
+		v, err := aArg.URLE()
+		if nil != err {
+			return err
+		}
+		aField.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	switch aField.Kind() {
+	case reflect.Bool:
+		if "" == aArg {
+			// A flag without argument means `true`
+			aField.SetBool(true)
+			return nil
+		}
+		v, err := aArg.BoolE()
+		if nil != err {
+			return err
+		}
+		aField.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := aArg.Int64E()
+		if nil != err {
+			return err
+		}
+		if aField.OverflowInt(v) {
+			return ErrInvalidValue{Arg: string(aArg), Kind: aField.Type().String(), Err: strconv.ErrRange}
+		}
+		aField.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := aArg.Uint64E()
+		if nil != err {
+			return err
+		}
+		if aField.OverflowUint(v) {
+			return ErrInvalidValue{Arg: string(aArg), Kind: aField.Type().String(), Err: strconv.ErrRange}
+		}
+		aField.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		v, err := aArg.FloatE()
+		if nil != err {
+			return err
+		}
+		aField.SetFloat(v)
+
+	case reflect.String:
+		aField.SetString(aArg.String())
+	}
+
+	return nil
+} // setField()
+
+// --------------------------------------------------------------------
+// tBindings methods
+
+// `pattern()` returns the options pattern built from all bindings.
+//
+// Returns:
+//   - `string`: The options pattern.
+func (bl *tBindings) pattern() string {
+	patterns := make([]string, 0, len(bl.list))
+	for _, b := range bl.list {
+		patterns = append(patterns, b.pattern)
+	}
+
+	return strings.Join(patterns, `|`)
+} // pattern()
+
+// --------------------------------------------------------------------
+// public functions
+
+// `Bind()` fills the fields of the struct `aConfig` points to with the
+// options of the application's commandline.
+//
+// See [Unmarshal] for details.
+//
+// Parameters:
+//   - `aConfig`: A pointer to the struct to fill.
+//
+// Returns:
+//   - `error`: A possible error during processing.
+func Bind(aConfig any) error {
+	gMtx.Lock()
+	args := gParser.args
+	gMtx.Unlock()
+
+	return Unmarshal(args, aConfig)
+} // Bind()
+
+// `Unmarshal()` fills the fields of the struct `aConfig` points to with
+// the options found in `aArgList`.
+//
+// The fields to fill are marked by a `getopts` struct tag listing the
+// option's names like in a pattern, optionally followed by the keyword
+// `required`, for example:
+//
+//	type tConfig struct {
+//		Output  string   `getopts:"o,-output,required"`
+//		Port    int      `getopts:"p,-port"`
+//		Verbose bool     `getopts:"v,-verbose"`
+//		Files   []string `getopts:",operands"`
+//	}
+//
+// The options pattern is built automatically from these tags: `bool`
+// fields are flag options while all other fields (integers, floats,
+// strings, `time.Duration`, `time.Time`, and `*url.URL`) require an
+// argument. A slice of those types (apart from `bool`) declares a
+// repeatable option collecting all its arguments; with the keyword
+// `split` each argument is split up at its commas as well. A `[]string`
+// field tagged with the keyword `operands` receives the commandline's
+// operands. An integer field tagged with the keyword `count` declares a
+// flag option whose number of uses is assigned (e.g. `-vvv` yields `3`).
+//
+// Arguments which can't be converted to the field's type are reported
+// as [ErrInvalidValue] errors (and leave the field unchanged).
+//
+// Fields whose option is not given on the commandline keep their value,
+// so they can be preset with default values. If a non-repeatable option
+// is given more than once its last occurrence wins.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments (the first
+//     element being the application's name).
+//   - `aConfig`: A pointer to the struct to fill.
+//
+// Returns:
+//   - `error`: A possible error during processing.
+func Unmarshal(aArgList []string, aConfig any) error {
+	bl, err := newBindings(aConfig)
+	if nil != err {
+		return err
+	}
+	eo := newExpectedOpts(bl.pattern())
+	for _, b := range bl.list {
+		if b.count || (reflect.Bool == b.field.Kind()) {
+			// Accept e.g. `--verbose=2` or `--debug=no`:
+			eo.specs[b.opt].valued = true
+		}
+	}
+	p := newParser(aArgList, eo)
+	var errs []error
+	if err := p.Validate(); nil != err {
+		errs = append(errs, err)
+	}
+
+	for _, b := range bl.list {
+		args := p.Values(string(b.opt))
+		if 0 == len(args) {
+			continue
+		}
+		if b.count {
+			b.field.SetInt(int64(p.Count(string(b.opt))))
+			continue
+		}
+		if err := b.set(args); nil != err {
+			errs = append(errs, fmt.Errorf("option %q: %w", b.opt.flag(), err))
+		}
+	}
+
+	if bl.operands.IsValid() {
+		bl.operands.Set(reflect.ValueOf(p.Operands()))
+	}
+
+	return errors.Join(errs...)
+} // Unmarshal()
+
+/* _EoF_ */
@@ -0,0 +1,244 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type tTestConfig struct {
+	Output  string   `getopts:"o,-output,required"`
+	Port    int      `getopts:"p,-port"`
+	Limit   uint16   `getopts:"l,-limit"`
+	Ratio   float64  `getopts:"r,-ratio"`
+	Verbose bool     `getopts:"v,-verbose"`
+	Quiet   bool     `getopts:"q"`
+	Files   []string `getopts:",operands"`
+	ignored string
+}
+
+func Test_newBindings(t *testing.T) {
+	var (
+		c1 tTestConfig
+		c2 struct {
+			Fn func() `getopts:"f"`
+		}
+		c3 struct {
+			Name string `getopts:"required"`
+		}
+	)
+	w1 := `o,-output:!|p,-port:|l,-limit:|r,-ratio:|v,-verbose|q`
+
+	tests := []struct {
+		name    string
+		config  any
+		want    string
+		wantErr bool
+	}{
+		{"1", &c1, w1, false},
+		{"2", c1, "", true},
+		{"3", &c2, "", true},
+		{"4", &c3, "", true},
+		{"5", nil, "", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newBindings(tt.config)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: newBindings() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if nil == got {
+				return
+			}
+			if gotPattern := got.pattern(); gotPattern != tt.want {
+				t.Errorf("%q: newBindings() = %q, want %q",
+					tt.name, gotPattern, tt.want)
+			}
+		})
+	}
+} // Test_newBindings()
+
+func TestUnmarshal_types(t *testing.T) {
+	var cfg struct {
+		Timeout time.Duration `getopts:"t,-timeout"`
+		Since   time.Time     `getopts:"-since"`
+		Server  *url.URL      `getopts:"-server"`
+	}
+	args := []string{`app`, `-t`, `30s`, `--since=2024-08-09`, `--server`, `https://example.com/`}
+
+	if err := Unmarshal(args, &cfg); nil != err {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if 30*time.Second != cfg.Timeout {
+		t.Errorf("Unmarshal() Timeout = %v, want %v", cfg.Timeout, 30*time.Second)
+	}
+	if w := time.Date(2024, 8, 9, 0, 0, 0, 0, time.UTC); !cfg.Since.Equal(w) {
+		t.Errorf("Unmarshal() Since = %v, want %v", cfg.Since, w)
+	}
+	if (nil == cfg.Server) || ("example.com" != cfg.Server.Host) {
+		t.Errorf("Unmarshal() Server = %v, want %q", cfg.Server, "example.com")
+	}
+} // TestUnmarshal_types()
+
+func TestUnmarshal_integers(t *testing.T) {
+	type tInts struct {
+		Big   uint64 `getopts:"-big"`
+		Hex   int    `getopts:"-hex"`
+		Small int8   `getopts:"-small"`
+		Mask  uint8  `getopts:"-mask"`
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    tInts
+		wantErr error
+	}{
+		{"1", []string{`app`, `--big`, `18446744073709551615`, `--hex`, `0x10`, `--small=-128`, `--mask=0b101`}, tInts{Big: 18446744073709551615, Hex: 16, Small: -128, Mask: 5}, nil},
+		{"2", []string{`app`, `--small`, `128`}, tInts{}, strconv.ErrRange},
+		{"3", []string{`app`, `--mask`, `-1`}, tInts{}, strconv.ErrSyntax},
+		{"4", []string{`app`, `--big`, `18446744073709551616`}, tInts{}, strconv.ErrRange},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got tInts
+			err := Unmarshal(tt.args, &got)
+			if nil == tt.wantErr {
+				if nil != err {
+					t.Errorf("%q: Unmarshal() error = %v, want nil",
+						tt.name, err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: Unmarshal() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: Unmarshal() = %+v, want %+v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestUnmarshal_integers()
+
+func TestUnmarshal_slices(t *testing.T) {
+	var cfg struct {
+		Include []string `getopts:"I,-include"`
+		Tags    []string `getopts:"-tags,split"`
+		Ports   []int    `getopts:"p"`
+		Verbose int      `getopts:"v,-verbose,count"`
+	}
+	args := []string{`app`, `-Ia`, `--include`, `b`, `--tags=x,y`, `--tags`, `z`, `-p`, `1`, `-p2`, `-vv`, `--verbose`}
+
+	if err := Unmarshal(args, &cfg); nil != err {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if w := []string{"a", "b"}; !reflect.DeepEqual(cfg.Include, w) {
+		t.Errorf("Unmarshal() Include = %q, want %q", cfg.Include, w)
+	}
+	if w := []string{"x", "y", "z"}; !reflect.DeepEqual(cfg.Tags, w) {
+		t.Errorf("Unmarshal() Tags = %q, want %q", cfg.Tags, w)
+	}
+	if w := []int{1, 2}; !reflect.DeepEqual(cfg.Ports, w) {
+		t.Errorf("Unmarshal() Ports = %v, want %v", cfg.Ports, w)
+	}
+	if 3 != cfg.Verbose {
+		t.Errorf("Unmarshal() Verbose = %d, want %d", cfg.Verbose, 3)
+	}
+} // TestUnmarshal_slices()
+
+func TestUnmarshal_env(t *testing.T) {
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("APP_DEBUG", "1")
+	var cfg struct {
+		Port  int  `getopts:"p,-port,$APP_PORT,required"`
+		Debug bool `getopts:"d,$APP_DEBUG"`
+		Quiet bool `getopts:"q,$APP_QUIET"`
+	}
+
+	if err := Unmarshal([]string{`app`}, &cfg); nil != err {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if (9090 != cfg.Port) || !cfg.Debug || cfg.Quiet {
+		t.Errorf("Unmarshal() = %+v, want {Port:9090 Debug:true Quiet:false}", cfg)
+	}
+} // TestUnmarshal_env()
+
+func TestUnmarshal(t *testing.T) {
+	a1 := []string{`app`, `--output=out.txt`, `-p`, `8080`, `-vq`, `--ratio`, `0.5`, `a.txt`, `-l`, `12`, `b.txt`}
+	w1 := tTestConfig{
+		Output:  "out.txt",
+		Port:    8080,
+		Limit:   12,
+		Ratio:   0.5,
+		Verbose: true,
+		Quiet:   true,
+		Files:   []string{`a.txt`, `b.txt`},
+	}
+
+	a2 := []string{`app`, `-p`, `1`, `--verbose=no`}
+	w2 := tTestConfig{Port: 1, Files: []string{}}
+
+	a3 := []string{`app`, `-o`, `x`, `--outfile`, `y`}
+	w3 := tTestConfig{Output: "x", Files: []string{`y`}}
+
+	a4 := []string{`app`, `-o`, `x`, `--port`, `abc`, `-v`}
+	w4 := tTestConfig{Output: "x", Verbose: true, Files: []string{}}
+
+	a5 := []string{`app`, `-o`, `x`, `--limit`, `70000`}
+	w5 := tTestConfig{Output: "x", Files: []string{}}
+
+	a6 := []string{`app`, `-o`, `x`, `--verbose=yes`, `-q`, `--verbose=no`}
+	w6 := tTestConfig{Output: "x", Quiet: true, Files: []string{}}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    tTestConfig
+		wantErr error
+	}{
+		{"1", a1, w1, nil},
+		{"2", a2, w2, ErrRequiredOption{Opt: "o"}},
+		{"3", a3, w3, ErrUnknownOption{Opt: "-outfile"}},
+		{"4", a4, w4, strconv.ErrSyntax},
+		{"5", a5, w5, strconv.ErrRange},
+		{"6", a6, w6, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got tTestConfig
+			err := Unmarshal(tt.args, &got)
+			if nil == tt.wantErr {
+				if nil != err {
+					t.Errorf("%q: Unmarshal() error = %v, want nil",
+						tt.name, err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: Unmarshal() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: Unmarshal() =\n%#v\n want \n%#v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestUnmarshal()
+
+/* _EoF_ */
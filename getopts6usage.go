@@ -0,0 +1,279 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TUsage` implements the `IHelpShower` interface by printing the
+	// help text generated from a parser's expected options.
+	//
+	// It can be used as the global [HelpShower] like this:
+	//
+	//	getopts.HelpShower = getopts.TUsage{}
+	TUsage struct {
+		// The parser to generate the help text for; if `nil` the
+		// parser calling it as [HelpShower] is used or else the
+		// internal default parser used by [Get].
+		Parser *TParser
+
+		// The writer to print the help text to; if `nil` the help
+		// text is printed to `os.Stdout`.
+		Writer io.Writer
+	}
+)
+
+// --------------------------------------------------------------------
+// TUsage methods
+
+// `ShowHelp()` prints the generated help text.
+//
+// If it's called as [HelpShower] by [TParser.Get] without a parser of
+// its own, the help text of the calling parser is printed; otherwise
+// a `nil` parser means the internal default parser used by [Get].
+//
+// Returns:
+//   - `error`: A possible error while writing the help text.
+func (u TUsage) ShowHelp() error {
+	w := u.Writer
+	if nil == w {
+		w = os.Stdout
+	}
+	var text string
+	if nil == u.Parser {
+		gMtx.Lock()
+		text = gParser.Usage()
+		gMtx.Unlock()
+	} else {
+		text = u.Parser.Usage()
+	}
+	_, err := io.WriteString(w, text)
+
+	return err
+} // ShowHelp()
+
+// --------------------------------------------------------------------
+// helper functions
+
+// `helpShowerFor()` returns the global [HelpShower] to use for the
+// given parser.
+//
+// A [TUsage] without a parser of its own is bound to `aParser` so that
+// it prints the help text of the parser processing the help option
+// (and doesn't touch the guarded default parser).
+//
+// Parameters:
+//   - `aParser`: The parser which found the help option.
+//
+// Returns:
+//   - `IHelpShower`: The help shower to call.
+func helpShowerFor(aParser *TParser) IHelpShower {
+	switch hs := HelpShower.(type) {
+	case TUsage:
+		if nil == hs.Parser {
+			hs.Parser = aParser
+			return hs
+		}
+	case *TUsage:
+		if (nil != hs) && (nil == hs.Parser) {
+			return TUsage{Parser: aParser, Writer: hs.Writer}
+		}
+	}
+
+	return HelpShower
+} // helpShowerFor()
+
+// --------------------------------------------------------------------
+// tOptSpec methods
+
+// `synopsis()` returns the option's names and argument as shown in
+// the help text, e.g. `-o, --output=FILE`.
+//
+// Returns:
+//   - `string`: The option's synopsis.
+func (sp *tOptSpec) synopsis() string {
+	names := sp.names()
+	flags := make([]string, 0, len(names))
+	for _, name := range names {
+		flags = append(flags, name.flag())
+	}
+	result := strings.Join(flags, ", ")
+	if argNone == sp.argMode {
+		return result
+	}
+
+	arg := sp.placeholder
+	if "" == arg {
+		arg = "ARG"
+	}
+	sep := " "
+	if '-' == names[len(names)-1][0] {
+		// The last name is a long option
+		sep = "="
+	}
+	if argOptional == sp.argMode {
+		if "=" == sep {
+			return result + "[=" + arg + "]"
+		}
+		return result + " [" + arg + "]"
+	}
+
+	return result + sep + arg
+} // synopsis()
+
+// `summary()` returns the option's description with the notes about
+// its choices, whether it's required, and whether it's repeatable.
+//
+// Returns:
+//   - `string`: The option's description.
+func (sp *tOptSpec) summary() string {
+	result := sp.description
+	if "" == result {
+		switch sp.name {
+		case `h`, `-help`:
+			result = "show this help text"
+		}
+	}
+	if 0 < len(sp.choices) {
+		result = strings.TrimSpace(fmt.Sprintf("%s (one of: %s)", result, strings.Join(sp.choices, ", ")))
+	}
+	if sp.required {
+		result = strings.TrimSpace(result + " (required)")
+	}
+	if sp.repeat {
+		result = strings.TrimSpace(result + " (repeatable)")
+	}
+
+	return result
+} // summary()
+
+// `usage()` returns the option's description as shown in the
+// help text.
+//
+// Parameters:
+//   - `aEnvPrefix`: The prefix of automatically mapped environment variables.
+//
+// Returns:
+//   - `string`: The option's description.
+func (sp *tOptSpec) usage(aEnvPrefix string) string {
+	result := sp.summary()
+	if "" != sp.defValue {
+		result = strings.TrimSpace(fmt.Sprintf("%s (default: %s)", result, sp.defValue))
+	}
+	if env := sp.envName(aEnvPrefix); "" != env {
+		result = strings.TrimSpace(fmt.Sprintf("%s (env: %s)", result, env))
+	}
+
+	return result
+} // usage()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `name()` returns the application's name as given by the first
+// element of the parser's argument list.
+//
+// Returns:
+//   - `string`: The application's name.
+func (p *TParser) name() string {
+	if (0 < len(p.args)) && ("" != p.args[0]) {
+		return filepath.Base(p.args[0])
+	}
+
+	return filepath.Base(os.Args[0])
+} // name()
+
+// `usageCommands()` writes the list of subcommands (if any) for
+// the help text.
+//
+// Parameters:
+//   - `aSB`: The builder to write to.
+func (p *TParser) usageCommands(aSB *strings.Builder) {
+	width := 0
+	for _, c := range p.commands {
+		if (nil != c) && (len([]rune(c.Name)) > width) {
+			width = len([]rune(c.Name))
+		}
+	}
+	if 0 == width {
+		return
+	}
+
+	aSB.WriteString("\nCommands:\n")
+	for _, c := range p.commands {
+		if nil == c {
+			continue
+		}
+		line := fmt.Sprintf("  %-*s  %s", width, c.Name, c.Description)
+		aSB.WriteString(strings.TrimRight(line, " "))
+		aSB.WriteString("\n")
+	}
+} // usageCommands()
+
+// `Usage()` returns a help text generated from the expected options.
+//
+// The text consists of a usage line followed by a list of all options
+// (in the order of their declaration) with their names, argument,
+// description, and default value, aligned in two columns:
+//
+//	Usage: myprog [OPTION]...
+//
+//	Options:
+//	  -o, --output=FILE  write the result to FILE (default: out.txt)
+//	  -v, --verbose      show more messages
+//	  -h, --help         show this help text
+//
+// Descriptions, argument names, and default values can be declared
+// by using [NewParserFor]. For a [TCommand] with subcommands those are
+// listed as well.
+//
+// Returns:
+//   - `string`: The generated help text.
+func (p *TParser) Usage() string {
+	var sb strings.Builder
+
+	if 0 < len(p.commands) {
+		fmt.Fprintf(&sb, "Usage: %s [OPTION]... COMMAND [ARG]...\n", p.name())
+	} else {
+		fmt.Fprintf(&sb, "Usage: %s [OPTION]...\n", p.name())
+	}
+	p.usageCommands(&sb)
+
+	order := p.iter.expected.order
+	if 0 == len(order) {
+		return sb.String()
+	}
+
+	synopses := make([]string, len(order))
+	width := 0
+	for idx, sp := range order {
+		synopses[idx] = sp.synopsis()
+		if l := len([]rune(synopses[idx])); l > width {
+			width = l
+		}
+	}
+
+	sb.WriteString("\nOptions:\n")
+	for idx, sp := range order {
+		line := fmt.Sprintf("  %-*s  %s", width, synopses[idx], sp.usage(p.envPrefix))
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+} // Usage()
+
+/* _EoF_ */
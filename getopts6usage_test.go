@@ -0,0 +1,121 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"bytes"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_tOptSpec_synopsis(t *testing.T) {
+	tests := []struct {
+		name    string
+		element string
+		arg     string
+		want    string
+	}{
+		{"1", "v", "", "-v"},
+		{"2", "v,-verbose", "", "-v, --verbose"},
+		{"3", "o,-output:", "FILE", "-o, --output=FILE"},
+		{"4", "o:", "", "-o ARG"},
+		{"5", "c,-color::", "WHEN", "-c, --color[=WHEN]"},
+		{"6", "c::", "WHEN", "-c [WHEN]"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := newOptSpec(tt.element)
+			sp.placeholder = tt.arg
+			if got := sp.synopsis(); got != tt.want {
+				t.Errorf("%q: tOptSpec.synopsis() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_tOptSpec_synopsis()
+
+func TestTParser_Usage(t *testing.T) {
+	o1 := []TOption{
+		{Pattern: "o,-output:", Arg: "FILE", Description: "write the result to FILE", Default: "out.txt"},
+		{Pattern: "I,-include:*", Arg: "DIR", Description: "add DIR to the search path"},
+		{Pattern: "v,-verbose", Description: "show more messages"},
+		{Pattern: "h,-help"},
+	}
+	w1 := `Usage: myprog [OPTION]...
+
+Options:
+  -o, --output=FILE  write the result to FILE (default: out.txt)
+  -I, --include=DIR  add DIR to the search path (repeatable)
+  -v, --verbose      show more messages
+  -h, --help         show this help text
+`
+	w2 := `Usage: myprog [OPTION]...
+
+Options:
+  -a
+  -b
+`
+	w3 := `Usage: myprog [OPTION]...
+
+Options:
+  -p, --port=ARG  (default: 8080)
+`
+	w4 := `Usage: myprog [OPTION]...
+
+Options:
+  -o ARG  (required)
+`
+
+	tests := []struct {
+		name   string
+		parser *TParser
+		want   string
+	}{
+		{"1", NewParserFor([]string{`/usr/bin/myprog`}, o1), w1},
+		{"2", NewParser([]string{`myprog`}, "a|b"), w2},
+		{"3", NewParser([]string{`myprog`}, "p,-port:=8080"), w3},
+		{"4", NewParser([]string{`myprog`}, "o:!"), w4},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.parser.Usage(); got != tt.want {
+				t.Errorf("%q: TParser.Usage() =\n%s\n want \n%s",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTParser_Usage()
+
+func TestTUsage_ShowHelp(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewParser([]string{`myprog`, `-h`}, "h,-help")
+	u := TUsage{Parser: p, Writer: &buf}
+
+	if err := u.ShowHelp(); nil != err {
+		t.Fatalf("TUsage.ShowHelp() error = %v", err)
+	}
+	if got, want := buf.String(), p.Usage(); got != want {
+		t.Errorf("TUsage.ShowHelp() =\n%s\n want \n%s", got, want)
+	}
+
+	// As help shower without a parser it uses the calling one:
+	buf.Reset()
+	oldShower := HelpShower
+	HelpShower = TUsage{Writer: &buf}
+	defer func() { HelpShower = oldShower }()
+	p = NewParser([]string{`myprog`, `-h`}, "h,-help|x,-extra")
+	p.Get()
+	if got, want := buf.String(), p.Usage(); got != want {
+		t.Errorf("TParser.Get() help =\n%s\n want \n%s", got, want)
+	}
+} // TestTUsage_ShowHelp()
+
+/* _EoF_ */
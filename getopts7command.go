@@ -0,0 +1,172 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TCommand` is a node in a tree of (sub)commands like the ones
+	// used by e.g. `git` (`git remote add -f origin URL`).
+	//
+	// Each command has its own options, its own help shower, and its
+	// own handler function. The options given before a subcommand's
+	// name are processed by the parent command and remain accessible
+	// to the subcommand's handler by [TParser.Parent].
+	TCommand struct {
+		// The command's name as used on the commandline; it's
+		// ignored for the root command.
+		Name string
+
+		// A short description of the command (for the help text).
+		Description string
+
+		// The pattern declaring the command's options; it's ignored
+		// if `Options` is not empty.
+		Pattern string
+
+		// The detailed declarations of the command's options.
+		Options []TOption
+
+		// The help shower to use if help was requested for this
+		// command; if `nil` the generated help text is printed.
+		HelpShower IHelpShower
+
+		// The function handling the command; it's called with the
+		// parser holding the command's options and operands.
+		Handler func(aParser *TParser) error
+
+		// The command's subcommands.
+		Commands []*TCommand
+	}
+)
+
+// --------------------------------------------------------------------
+// TCommand methods
+
+// `command()` returns the subcommand with the given name.
+//
+// Parameters:
+//   - `aName`: The name of the subcommand to look for.
+//
+// Returns:
+//   - `*TCommand`: The subcommand or `nil` if there's none.
+func (c *TCommand) command(aName string) *TCommand {
+	for _, sub := range c.Commands {
+		if (nil != sub) && (aName == sub.Name) {
+			return sub
+		}
+	}
+
+	return nil
+} // command()
+
+// `parser()` returns a parser for the command's options.
+//
+// Parameters:
+//   - `aArgList`: The command's name followed by its options and operands.
+//   - `aParent`: The parser of the parent command (if any).
+//
+// Returns:
+//   - `*TParser`: The new parser instance.
+func (c *TCommand) parser(aArgList []string, aParent *TParser) *TParser {
+	var eo *tExpectedOpts
+	if 0 < len(c.Options) {
+		eo = newExpectedOptsFor(c.Options)
+	} else {
+		eo = newExpectedOpts(c.Pattern)
+	}
+	// With subcommands the options end at the first operand:
+	eo.stopAtOperand = (0 < len(c.Commands))
+	// A subcommand without any words is a valid call of its handler:
+	eo.noHelpDefault = (nil != aParent)
+
+	p := &TParser{
+		args:     append([]string(nil), aArgList...),
+		iter:     newIterator(&tOptArgList{}),
+		parent:   aParent,
+		commands: c.Commands,
+	}
+
+	return p.setExpected(eo)
+} // parser()
+
+// `Run()` processes the given argument list and calls the handler
+// of the addressed (sub)command.
+//
+// The options given before the first operand are processed according
+// to the command's options. If the command has subcommands, the first
+// operand selects the subcommand which in turn processes all remaining
+// words. If help was requested, the command's help shower is called
+// instead of its handler. An empty commandline counts as a help request
+// for the root command only; a subcommand given without any words
+// (e.g. `myprog status`) is passed to its handler.
+//
+// Parameters:
+//   - `aArgList`: A list of commandline options and arguments (the first
+//     element being the application's name), e.g. `os.Args`.
+//
+// Returns:
+//   - `error`: A possible error while processing the commandline or
+//     the error returned by the command's handler.
+func (c *TCommand) Run(aArgList []string) error {
+	return c.run(aArgList, nil)
+} // Run()
+
+// `run()` processes the given argument list for this command.
+//
+// Parameters:
+//   - `aArgList`: The command's name followed by its options and operands.
+//   - `aParent`: The parser of the parent command (if any).
+//
+// Returns:
+//   - `error`: A possible processing or handler error.
+func (c *TCommand) run(aArgList []string, aParent *TParser) error {
+	p := c.parser(aArgList, aParent)
+
+	if p.completionRequested() && c.hasCompleter() {
+		return showCandidates(c.candidates(p.name(), p.args[2:], nil))
+	}
+	if shell, ok := p.Lookup(string(completionOpt)); ok {
+		return p.showCompletion(string(shell))
+	}
+	if p.IsHelp() {
+		if nil != c.HelpShower {
+			return c.HelpShower.ShowHelp()
+		}
+		return TUsage{Parser: p}.ShowHelp()
+	}
+	if err := p.Err(); nil != err {
+		return err
+	}
+
+	if 0 == len(c.Commands) {
+		if nil == c.Handler {
+			return nil
+		}
+		return c.Handler(p)
+	}
+
+	operands := p.operands
+	if 0 == len(operands) {
+		if nil != c.Handler {
+			return c.Handler(p)
+		}
+		return ErrUnknownCommand{}
+	}
+
+	sub := c.command(operands[0])
+	if nil == sub {
+		return ErrUnknownCommand{Cmd: operands[0]}
+	}
+	// The subcommand's argument list starts with its full name:
+	subArgs := append([]string{p.name() + " " + sub.Name}, operands[1:]...)
+
+	return sub.run(subArgs, p)
+} // run()
+
+/* _EoF_ */
@@ -0,0 +1,125 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tHelpRecorder` is a help shower recording its calls.
+type tHelpRecorder struct {
+	log *[]string
+}
+
+func (hr tHelpRecorder) ShowHelp() error {
+	*hr.log = append(*hr.log, "help")
+	return nil
+} // ShowHelp()
+
+// `prepCommands()` returns a command tree recording its handlers' calls.
+func prepCommands(aLog *[]string) *TCommand {
+	record := func(aName string) func(*TParser) error {
+		return func(aParser *TParser) error {
+			entry := aName + ":" + strings.Join(aParser.Operands(), " ")
+			if _, ok := aParser.Lookup("f"); ok {
+				entry += ":f"
+			}
+			if parent := aParser.Parent(); nil != parent {
+				if _, ok := parent.Lookup("v"); ok {
+					entry += ":v"
+				}
+			}
+			*aLog = append(*aLog, entry)
+			return nil
+		}
+	}
+
+	return &TCommand{
+		Pattern: "v,-verbose|h,-help",
+		Commands: []*TCommand{
+			{
+				Name:        "add",
+				Description: "add a remote",
+				Pattern:     "f,-fetch|h,-help",
+				HelpShower:  tHelpRecorder{aLog},
+				Handler:     record("add"),
+			},
+			{
+				Name:        "remove",
+				Description: "remove a remote",
+				Handler:     record("remove"),
+			},
+		},
+	}
+} // prepCommands()
+
+func TestTCommand_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{"1", []string{`myprog`, `add`, `-f`, `origin`, `URL`}, []string{"add:origin URL:f"}, nil},
+		{"2", []string{`myprog`, `-v`, `add`, `origin`}, []string{"add:origin:v"}, nil},
+		{"3", []string{`myprog`, `remove`, `origin`, `--`, `-v`}, []string{"remove:origin -v"}, nil},
+		{"4", []string{`myprog`, `-v`}, nil, ErrUnknownCommand{}},
+		{"5", []string{`myprog`, `list`}, nil, ErrUnknownCommand{Cmd: "list"}},
+		{"6", []string{`myprog`, `-x`, `add`}, nil, ErrUnknownOption{Opt: "x"}},
+		{"7", []string{`myprog`, `add`, `-x`}, nil, ErrUnknownOption{Opt: "x"}},
+		{"8", []string{`myprog`, `add`, `--help`, `origin`}, []string{"help"}, nil},
+		{"9", []string{`myprog`, `remove`, `-v`}, nil, ErrUnknownOption{Opt: "v"}},
+		{"10", []string{`myprog`, `remove`}, []string{"remove:"}, nil},
+		{"11", []string{`myprog`, `-v`, `add`}, []string{"add::v"}, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := prepCommands(&got).Run(tt.args)
+			if nil == tt.wantErr {
+				if nil != err {
+					t.Errorf("%q: TCommand.Run() error = %v, want nil",
+						tt.name, err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TCommand.Run() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TCommand.Run() calls = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTCommand_Run()
+
+func TestTCommand_parser(t *testing.T) {
+	var calls []string
+	p := prepCommands(&calls).parser([]string{`myprog`, `--help`}, nil)
+	want := `Usage: myprog [OPTION]... COMMAND [ARG]...
+
+Commands:
+  add     add a remote
+  remove  remove a remote
+
+Options:
+  -v, --verbose
+  -h, --help     show this help text
+`
+	if got := p.Usage(); got != want {
+		t.Errorf("TParser.Usage() =\n%s\n want \n%s", got, want)
+	}
+} // TestTCommand_parser()
+
+/* _EoF_ */
@@ -0,0 +1,101 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"os"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// --------------------------------------------------------------------
+// tOptSpec methods
+
+// `envName()` returns the name of the environment variable providing
+// the option's fallback value.
+//
+// An environment variable declared explicitly (e.g. by the pattern
+// `-output:$MYPROG_OUTPUT`) takes precedence. Otherwise, if `aPrefix`
+// is not empty, the name is built from the prefix and the option's
+// first long name (or its canonical name if there's no long one) in
+// upper case with hyphens replaced by underscores, e.g. `MYPROG_OUTPUT`
+// for the option `o,-output:` and the prefix `MYPROG`.
+//
+// Parameters:
+//   - `aPrefix`: The prefix of automatically mapped environment variables.
+//
+// Returns:
+//   - `string`: The variable's name or an empty string if there's none.
+func (sp *tOptSpec) envName(aPrefix string) string {
+	if "" != sp.env {
+		return sp.env
+	}
+	if aPrefix = strings.TrimRight(aPrefix, `_`); "" == aPrefix {
+		return ""
+	}
+
+	name := sp.name
+	for _, n := range sp.names() {
+		if strings.HasPrefix(string(n), `-`) {
+			name = n
+			break
+		}
+	}
+	name = tOpt(strings.TrimLeft(string(name), `-`))
+
+	return aPrefix + `_` + strings.ToUpper(strings.ReplaceAll(string(name), `-`, `_`))
+} // envName()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `addEnv()` appends the values of the environment variables of all
+// options not given on the commandline to the parser's option list.
+//
+// The value of an option requiring an argument is used as is, while an
+// empty value is ignored. For a flag option an integer value sets the
+// number of its uses (see [TParser.Count]) while any other value is
+// interpreted as a boolean (see [TArg.Bool]), e.g. `MYPROG_VERBOSE=1`
+// or `MYPROG_VERBOSE=yes` set the flag while `MYPROG_VERBOSE=0` or
+// `MYPROG_VERBOSE=no` don't.
+func (p *TParser) addEnv() {
+	for _, spec := range p.iter.expected.order {
+		if _, given := p.sources[spec.name]; given {
+			continue
+		}
+		env := spec.envName(p.envPrefix)
+		if "" == env {
+			continue
+		}
+		if value, ok := os.LookupEnv(env); ok {
+			p.addValues(spec, []string{value}, SourceEnv)
+		}
+	}
+} // addEnv()
+
+// `SetEnvPrefix()` sets up an automatic mapping of environment variables
+// to all options which don't declare an environment variable explicitly.
+//
+// For example with the prefix `MYPROG` the option `o,-output:` gets
+// its value from the variable `MYPROG_OUTPUT` if it's not given on the
+// commandline. Values given on the commandline always take precedence.
+//
+// Parameters:
+//   - `aPrefix`: The prefix of the environment variables' names
+//     (an empty prefix disables the automatic mapping).
+//
+// Returns:
+//   - `*TParser`: The parser instance with the updated options.
+func (p *TParser) SetEnvPrefix(aPrefix string) *TParser {
+	p.envPrefix = aPrefix
+
+	return p.setExpected(p.iter.expected)
+} // SetEnvPrefix()
+
+/* _EoF_ */
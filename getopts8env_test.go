@@ -0,0 +1,117 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_tOptSpec_envName(t *testing.T) {
+	tests := []struct {
+		name    string
+		element string
+		prefix  string
+		want    string
+	}{
+		{"1", "o,-output:", "", ""},
+		{"2", "o,-output:", "MYPROG", "MYPROG_OUTPUT"},
+		{"3", "o,-output:", "MYPROG_", "MYPROG_OUTPUT"},
+		{"4", "o:", "MYPROG", "MYPROG_O"},
+		{"5", "-dry-run", "MYPROG", "MYPROG_DRY_RUN"},
+		{"6", "o,-output:$OUT_FILE", "", "OUT_FILE"},
+		{"7", "o,-output:$OUT_FILE", "MYPROG", "OUT_FILE"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newOptSpec(tt.element).envName(tt.prefix); got != tt.want {
+				t.Errorf("%q: tOptSpec.envName() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_tOptSpec_envName()
+
+func TestTParser_SetEnvPrefix(t *testing.T) {
+	t.Setenv("MYPROG_OUTPUT", "env.txt")
+	t.Setenv("MYPROG_PORT", "")
+	t.Setenv("MYPROG_VERBOSE", "2")
+	t.Setenv("MYPROG_QUIET", "off")
+	t.Setenv("MYPROG_DRY_RUN", "yes")
+	t.Setenv("IN_FILE", "in.txt")
+
+	pattern := "o,-output:|p,-port:|v,-verbose|q,-quiet|-dry-run|i:$IN_FILE"
+	tests := []struct {
+		name   string
+		args   []string
+		prefix string
+		opt    string
+		want   TArg
+		wantOK bool
+		count  int
+	}{
+		{"1", []string{`app`}, "MYPROG", "o", "env.txt", true, 1},
+		{"2", []string{`app`, `-o`, `cmd.txt`}, "MYPROG", "o", "cmd.txt", true, 1},
+		{"3", []string{`app`}, "", "o", "", false, 0},
+		{"4", []string{`app`}, "MYPROG", "p", "", false, 0},
+		{"5", []string{`app`}, "MYPROG", "v", "", true, 2},
+		{"6", []string{`app`, `-v`}, "MYPROG", "v", "", true, 1},
+		{"7", []string{`app`}, "MYPROG", "q", "", false, 0},
+		{"8", []string{`app`}, "MYPROG", "-dry-run", "", true, 1},
+		{"9", []string{`app`}, "", "i", "in.txt", true, 1},
+		{"10", []string{`app`, `-iarg`}, "", "i", "arg", true, 1},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.args, pattern).SetEnvPrefix(tt.prefix)
+			got, ok := p.Lookup(tt.opt)
+			if (got != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("%q: TParser.Lookup() = %q, %v, want %q, %v",
+					tt.name, got, ok, tt.want, tt.wantOK)
+			}
+			if got := p.Count(tt.opt); got != tt.count {
+				t.Errorf("%q: TParser.Count() = %d, want %d",
+					tt.name, got, tt.count)
+			}
+			if err := p.Err(); nil != err {
+				t.Errorf("%q: TParser.Err() = %v, want nil",
+					tt.name, err)
+			}
+		})
+	}
+} // TestTParser_SetEnvPrefix()
+
+func TestNewParserFor_env(t *testing.T) {
+	t.Setenv("OUT_FILE", "env.txt")
+
+	p := NewParserFor([]string{`app`}, []TOption{
+		{Pattern: "o,-output:", Arg: "FILE", Env: "OUT_FILE"},
+	})
+	if got, _ := p.Lookup("-output"); "env.txt" != got {
+		t.Errorf("TParser.Lookup() = %q, want %q", got, "env.txt")
+	}
+
+	opt, arg, _ := p.Get()
+	if ("o" != opt) || ("env.txt" != arg) {
+		t.Errorf("TParser.Get() = %q, %q, want %q, %q", opt, arg, "o", "env.txt")
+	}
+
+	want := `Usage: app [OPTION]...
+
+Options:
+  -o, --output=FILE  (env: OUT_FILE)
+`
+	if got := p.Usage(); got != want {
+		t.Errorf("TParser.Usage() =\n%s\n want \n%s", got, want)
+	}
+} // TestNewParserFor_env()
+
+/* _EoF_ */
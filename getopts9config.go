@@ -0,0 +1,436 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TSource` tells where an option's value came from.
+	TSource uint8
+)
+
+const (
+	// The option wasn't given at all.
+	SourceNone TSource = iota
+
+	// The option was given on the commandline.
+	SourceCommandline
+
+	// The option was set by an environment variable.
+	SourceEnv
+
+	// The option was set by a configuration file.
+	SourceFile
+
+	// The option was set by its declared default value.
+	SourceDefault
+)
+
+// --------------------------------------------------------------------
+// TSource methods
+
+// `String()` returns the source's name.
+//
+// Returns:
+//   - `string`: The source's name.
+func (s TSource) String() string {
+	switch s {
+	case SourceCommandline:
+		return "commandline"
+	case SourceEnv:
+		return "environment"
+	case SourceFile:
+		return "file"
+	case SourceDefault:
+		return "default"
+	}
+
+	return "none"
+} // String()
+
+// --------------------------------------------------------------------
+// config file parsing
+
+// `parseConfig()` parses the contents of a configuration file.
+//
+// Files with the extension `.json` are expected to hold a JSON object
+// while all other files are read as INI or (a subset of) TOML files.
+//
+// Parameters:
+//   - `aFilename`: The file's name (used to determine its format).
+//   - `aData`: The file's contents.
+//
+// Returns:
+//   - `map[string][]string`: The values found by their key.
+//   - `error`: A possible syntax error.
+func parseConfig(aFilename string, aData []byte) (map[string][]string, error) {
+	if strings.EqualFold(".json", filepath.Ext(aFilename)) {
+		return parseJSON(aData)
+	}
+
+	return parseINI(aData)
+} // parseConfig()
+
+// `parseINI()` parses an INI or TOML file.
+//
+// Each line holds a `key = value` pair; empty lines and lines starting
+// with `#` or `;` are ignored. A `[section]` header prefixes the keys
+// of the following lines with `section.` (which therefore don't match
+// any option). Values may be given bare, in double quotes (with Go/TOML
+// escapes), in single quotes (literally), or as an array of values in
+// square brackets (e.g. `tags = ["a", "b"]`). A bare value may be
+// followed by a comment starting with ` #` or ` ;`.
+//
+// Parameters:
+//   - `aData`: The file's contents.
+//
+// Returns:
+//   - `map[string][]string`: The values found by their key.
+//   - `error`: A possible syntax error.
+func parseINI(aData []byte) (map[string][]string, error) {
+	result := make(map[string][]string)
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(aData))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if ("" == line) || ('#' == line[0]) || (';' == line[0]) {
+			continue
+		}
+		if '[' == line[0] {
+			end := strings.LastIndexByte(line, ']')
+			if 0 > end {
+				return nil, fmt.Errorf("line %d: invalid section %q", lineNo, line)
+			}
+			section = strings.Trim(line[:end+1], "[] \t") + "."
+			continue
+		}
+
+		pos := strings.IndexByte(line, '=')
+		if 0 >= pos {
+			return nil, fmt.Errorf("line %d: invalid entry %q", lineNo, line)
+		}
+		key := strings.Trim(strings.TrimSpace(line[:pos]), `"'`)
+		values, err := parseINIValue(strings.TrimSpace(line[pos+1:]))
+		if nil != err {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if "" != section {
+			key = section + key
+		}
+		result[key] = append(result[key], values...)
+	}
+
+	return result, scanner.Err()
+} // parseINI()
+
+// `parseINIValue()` parses the value part of an INI/TOML line.
+//
+// Parameters:
+//   - `aValue`: The text following the `=` sign.
+//
+// Returns:
+//   - `[]string`: The value(s) found.
+//   - `error`: A possible syntax error.
+func parseINIValue(aValue string) ([]string, error) {
+	if strings.HasPrefix(aValue, `[`) {
+		var result []string
+		rest := strings.TrimSpace(aValue[1:])
+		for !strings.HasPrefix(rest, `]`) {
+			if "" == rest {
+				return nil, fmt.Errorf("unterminated array %q", aValue)
+			}
+			value, tail, err := parseINIScalar(rest, `,]`)
+			if nil != err {
+				return nil, err
+			}
+			result = append(result, value)
+			rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tail), `,`))
+		}
+
+		return result, nil
+	}
+
+	value, _, err := parseINIScalar(aValue, "")
+	if nil != err {
+		return nil, err
+	}
+
+	return []string{value}, nil
+} // parseINIValue()
+
+// `parseINIScalar()` parses a single (possibly quoted) value.
+//
+// Parameters:
+//   - `aText`: The text starting with the value.
+//   - `aStop`: The characters ending a bare value (besides a comment).
+//
+// Returns:
+//   - `string`: The value found.
+//   - `string`: The text following the value.
+//   - `error`: A possible syntax error.
+func parseINIScalar(aText, aStop string) (string, string, error) {
+	if 0 == len(aText) {
+		// An empty value like `output =`
+		return "", "", nil
+	}
+
+	switch aText[0] {
+	case '"':
+		for pos := 1; pos < len(aText); pos++ {
+			switch aText[pos] {
+			case '\\':
+				pos++
+			case '"':
+				value, err := strconv.Unquote(aText[:pos+1])
+				if nil != err {
+					return "", "", fmt.Errorf("invalid string %s", aText[:pos+1])
+				}
+				return value, aText[pos+1:], nil
+			}
+		}
+		return "", "", fmt.Errorf("unterminated string %s", aText)
+
+	case '\'':
+		end := strings.IndexByte(aText[1:], '\'')
+		if 0 > end {
+			return "", "", fmt.Errorf("unterminated string %s", aText)
+		}
+		return aText[1 : end+1], aText[end+2:], nil
+	}
+
+	end := len(aText)
+	if pos := strings.IndexAny(aText, aStop); 0 <= pos {
+		end = pos
+	}
+	for _, comment := range []string{` #`, ` ;`, "\t#", "\t;"} {
+		if pos := strings.Index(aText[:end], comment); 0 <= pos {
+			end = pos
+		}
+	}
+
+	return strings.TrimSpace(aText[:end]), aText[end:], nil
+} // parseINIScalar()
+
+// `parseJSON()` parses a JSON file.
+//
+// The file must hold a JSON object. Strings, numbers, and booleans are
+// used as their textual representation, arrays provide several values,
+// nested objects prefix their keys with the object's key and a dot
+// (e.g. `{"server": {"port": 80}}` yields the key `server.port`), and
+// `null` values are ignored.
+//
+// Parameters:
+//   - `aData`: The file's contents.
+//
+// Returns:
+//   - `map[string][]string`: The values found by their key.
+//   - `error`: A possible syntax error.
+func parseJSON(aData []byte) (map[string][]string, error) {
+	var obj map[string]any
+
+	dec := json.NewDecoder(bytes.NewReader(aData))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); nil != err {
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	var add func(string, any)
+	add = func(aKey string, aValue any) {
+		switch v := aValue.(type) {
+		case nil:
+			// ignore empty values
+		case string:
+			result[aKey] = append(result[aKey], v)
+		case json.Number:
+			result[aKey] = append(result[aKey], v.String())
+		case bool:
+			result[aKey] = append(result[aKey], strconv.FormatBool(v))
+		case []any:
+			for _, elem := range v {
+				add(aKey, elem)
+			}
+		case map[string]any:
+			for key, elem := range v {
+				add(aKey+"."+key, elem)
+			}
+		}
+	}
+	for key, value := range obj {
+		add(key, value)
+	}
+
+	return result, nil
+} // parseJSON()
+
+// --------------------------------------------------------------------
+// TParser methods
+
+// `addConfig()` appends the values read from a configuration file for
+// all options neither given on the commandline nor by the environment.
+//
+// An option's value is looked up by each of its names without leading
+// hyphens, e.g. `output` or `o` for the option `o,-output:`.
+func (p *TParser) addConfig() {
+	if 0 == len(p.config) {
+		return
+	}
+
+	for _, spec := range p.iter.expected.order {
+		if _, given := p.sources[spec.name]; given {
+			continue
+		}
+		for _, name := range spec.names() {
+			if values, ok := p.config[strings.TrimLeft(string(name), `-`)]; ok {
+				p.addValues(spec, values, SourceFile)
+				break
+			}
+		}
+	}
+} // addConfig()
+
+// `addDefaults()` appends the default values of all options which are
+// not given by any other source.
+func (p *TParser) addDefaults() {
+	for _, spec := range p.iter.expected.order {
+		if _, given := p.sources[spec.name]; given || ("" == spec.defValue) {
+			continue
+		}
+		p.addValues(spec, []string{spec.defValue}, SourceDefault)
+	}
+} // addDefaults()
+
+// `addValues()` appends the given values of an option not given on the
+// commandline to the parser's option list and records their source.
+//
+// For an option taking an argument each non-empty value is added. For
+// a flag option an integer value sets the number of its uses (see
+// [TParser.Count]) while any other value is interpreted as a boolean
+// (see [TArg.Bool]).
+//
+// Parameters:
+//   - `aSpec`: The option to add.
+//   - `aValues`: The option's values.
+//   - `aSource`: Where the values came from.
+func (p *TParser) addValues(aSpec *tOptSpec, aValues []string, aSource TSource) {
+	for _, value := range aValues {
+		if "" == value {
+			continue
+		}
+		if argNone != aSpec.argMode {
+			*p.iter.optArgs = append(*p.iter.optArgs, tOptArg{aSpec.name, TArg(value)})
+			p.sources[aSpec.name] = aSource
+			continue
+		}
+
+		count := 0
+		if n, err := strconv.Atoi(value); nil == err {
+			count = n
+		} else if TArg(value).Bool() {
+			count = 1
+		}
+		for ; 0 < count; count-- {
+			*p.iter.optArgs = append(*p.iter.optArgs, tOptArg{aSpec.name, TArg("")})
+			p.sources[aSpec.name] = aSource
+		}
+	}
+} // addValues()
+
+// `LoadConfig()` reads the options from a configuration file.
+//
+// The file's name is taken from the option `aOpt` (e.g. `-config`) if
+// it's given (on the commandline or by the environment), otherwise the
+// option's default value or else `aDefaultPath` is used. A missing
+// default file is silently ignored while a missing file given by the
+// option is an error.
+//
+// Files with the extension `.json` are read as JSON objects, all other
+// files as INI or TOML files (supporting comments, sections, quoted
+// strings, and arrays). The keys are the option names without leading
+// hyphens, e.g.
+//
+//	output = "result.txt"
+//	verbose = true
+//	tags = ["a", "b"]
+//
+// The values read are used for all options which are neither given on
+// the commandline nor by an environment variable, i.e. the precedence
+// is commandline, environment, and then configuration file. Where an
+// option's value came from can be checked by [TParser.Source].
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) naming the
+//     configuration file (may be empty).
+//   - `aDefaultPath`: The file to use if the option is not given
+//     (may be empty).
+//
+// Returns:
+//   - `error`: A possible error reading or parsing the file.
+func (p *TParser) LoadConfig(aOpt, aDefaultPath string) error {
+	filename, given := TArg(""), false
+	if spec, ok := p.iter.expected.specs[tOpt(aOpt)]; ok {
+		switch p.Source(aOpt) {
+		case SourceCommandline, SourceEnv:
+			filename, given = p.Lookup(aOpt)
+		default:
+			// The option's default is a default path as well:
+			filename = TArg(spec.defValue)
+		}
+	}
+	if "" == filename {
+		if "" == aDefaultPath {
+			return nil
+		}
+		filename = TArg(aDefaultPath)
+	}
+
+	data, err := os.ReadFile(string(filename))
+	if nil != err {
+		if !given && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("getopts: %w", err)
+	}
+	config, err := parseConfig(string(filename), data)
+	if nil != err {
+		return fmt.Errorf("getopts: %s: %w", filename, err)
+	}
+	p.config = config
+
+	// Apply the file's values:
+	p.setExpected(p.iter.expected)
+
+	return nil
+} // LoadConfig()
+
+// `Source()` returns where the value of the given option came from.
+//
+// Parameters:
+//   - `aOpt`: The option's name (or one of its aliases) as used in the pattern.
+//
+// Returns:
+//   - `TSource`: The option's source ([SourceNone] if it's not given at all).
+func (p *TParser) Source(aOpt string) TSource {
+	return p.sources[p.iter.expected.canonical(tOpt(aOpt))]
+} // Source()
+
+/* _EoF_ */
@@ -0,0 +1,208 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+			All rights reserved
+		EMail : <support@mwat.de>
+*/
+
+package getopts
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_parseINI(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{"1", "", map[string][]string{}, false},
+		{"2", "# comment\n; comment\n\noutput = out.txt\n", map[string][]string{"output": {"out.txt"}}, false},
+		{"3", `output = "a \"b\".txt" # comment`, map[string][]string{"output": {`a "b".txt`}}, false},
+		{"4", `output = 'C:\tmp' ; comment`, map[string][]string{"output": {`C:\tmp`}}, false},
+		{"5", `tags = ["a", 'b', c ]`, map[string][]string{"tags": {"a", "b", "c"}}, false},
+		{"6", "port=80 # http\n[server]\nport = 8080", map[string][]string{"port": {"80"}, "server.port": {"8080"}}, false},
+		{"7", "I = a\nI = b", map[string][]string{"I": {"a", "b"}}, false},
+		{"8", "output", nil, true},
+		{"9", `output = "out.txt`, nil, true},
+		{"10", `tags = ["a", "b"`, nil, true},
+		{"11", "output =\nport=", map[string][]string{"output": {""}, "port": {""}}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseINI([]byte(tt.data))
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: parseINI() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: parseINI() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_parseINI()
+
+func Test_parseJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{"1", `{}`, map[string][]string{}, false},
+		{"2", `{"output": "out.txt", "port": 8080, "verbose": true, "none": null}`,
+			map[string][]string{"output": {"out.txt"}, "port": {"8080"}, "verbose": {"true"}}, false},
+		{"3", `{"tags": ["a", "b"], "server": {"port": 80}}`,
+			map[string][]string{"tags": {"a", "b"}, "server.port": {"80"}}, false},
+		{"4", `["a"]`, nil, true},
+		{"5", `{"a": `, nil, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSON([]byte(tt.data))
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: parseJSON() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: parseJSON() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_parseJSON()
+
+func TestTParser_LoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	iniFile := filepath.Join(dir, "app.ini")
+	jsonFile := filepath.Join(dir, "app.json")
+	if err := os.WriteFile(iniFile, []byte("output = file.txt\nport = 80\nverbose = 2\ntags = [\"a\", \"b\"]\n"), 0o600); nil != err {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonFile, []byte(`{"output": "json.txt", "quiet": false}`), 0o600); nil != err {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_PORT", "8080")
+
+	pattern := "c,-config:|o,-output:|p,-port:$APP_PORT|v,-verbose|q,-quiet|-tags:*"
+	tests := []struct {
+		name    string
+		args    []string
+		defPath string
+		opt     string
+		want    []TArg
+		wantSrc TSource
+		wantErr bool
+	}{
+		{"1", []string{`app`, `-c`, iniFile}, "", "o", []TArg{"file.txt"}, SourceFile, false},
+		{"2", []string{`app`, `-c`, iniFile, `-o`, `cmd.txt`}, "", "o", []TArg{"cmd.txt"}, SourceCommandline, false},
+		{"3", []string{`app`, `-c`, iniFile}, "", "p", []TArg{"8080"}, SourceEnv, false},
+		{"4", []string{`app`}, iniFile, "-tags", []TArg{"a", "b"}, SourceFile, false},
+		{"5", []string{`app`}, iniFile, "v", []TArg{""}, SourceFile, false},
+		{"6", []string{`app`, `--config=` + jsonFile}, iniFile, "o", []TArg{"json.txt"}, SourceFile, false},
+		{"7", []string{`app`, `--config=` + jsonFile}, "", "q", []TArg{}, SourceNone, false},
+		{"8", []string{`app`}, filepath.Join(dir, "missing.ini"), "o", []TArg{}, SourceNone, false},
+		{"9", []string{`app`, `-c`, filepath.Join(dir, "missing.ini")}, "", "o", []TArg{}, SourceNone, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.args, pattern)
+			if err := p.LoadConfig("c", tt.defPath); (nil != err) != tt.wantErr {
+				t.Errorf("%q: TParser.LoadConfig() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got := p.Values(tt.opt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TParser.Values() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+			if got := p.Source(tt.opt); got != tt.wantSrc {
+				t.Errorf("%q: TParser.Source() = %v, want %v",
+					tt.name, got, tt.wantSrc)
+			}
+		})
+	}
+
+	p := NewParser([]string{`app`}, pattern)
+	if err := p.LoadConfig("", iniFile); nil != err {
+		t.Fatalf("TParser.LoadConfig() error = %v", err)
+	}
+	if got := p.Count("v"); 2 != got {
+		t.Errorf("TParser.Count() = %d, want %d", got, 2)
+	}
+
+	// The option's default is a default path which may be missing:
+	p = NewParser([]string{`app`}, "c,-config:="+filepath.Join(dir, "missing.ini")+"|o,-output:")
+	if err := p.LoadConfig("c", iniFile); nil != err {
+		t.Errorf("TParser.LoadConfig() error = %v, want nil", err)
+	}
+	p = NewParser([]string{`app`}, "c,-config:="+iniFile+"|o,-output:")
+	if err := p.LoadConfig("c", ""); nil != err {
+		t.Errorf("TParser.LoadConfig() error = %v, want nil", err)
+	}
+	if got, _ := p.Lookup("o"); "file.txt" != got {
+		t.Errorf("TParser.Lookup() = %q, want %q", got, "file.txt")
+	}
+} // TestTParser_LoadConfig()
+
+func TestTParser_defaults(t *testing.T) {
+	t.Setenv("APP_PORT", "9090")
+
+	pattern := "o,-output:=out.txt|p,-port:=8080$APP_PORT|v,-verbose=2|-tags:*,=a,b|q|-price:=$5|-cost:=$5$APP_COST"
+	tests := []struct {
+		name    string
+		args    []string
+		opt     string
+		want    []TArg
+		wantSrc TSource
+	}{
+		{"1", []string{`app`}, "o", []TArg{"out.txt"}, SourceDefault},
+		{"2", []string{`app`, `--output=x.txt`}, "o", []TArg{"x.txt"}, SourceCommandline},
+		{"3", []string{`app`}, "-port", []TArg{"9090"}, SourceEnv},
+		{"4", []string{`app`}, "v", []TArg{""}, SourceDefault},
+		{"5", []string{`app`}, "-tags", []TArg{"a", "b"}, SourceDefault},
+		{"6", []string{`app`, `--tags`, `c`}, "-tags", []TArg{"c"}, SourceCommandline},
+		{"7", []string{`app`}, "q", []TArg{}, SourceNone},
+		{"8", []string{`app`}, "-price", []TArg{"$5"}, SourceDefault},
+		{"9", []string{`app`}, "-cost", []TArg{"$5"}, SourceDefault},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.args, pattern)
+			if got := p.Values(tt.opt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TParser.Values() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+			if got := p.Source(tt.opt); got != tt.wantSrc {
+				t.Errorf("%q: TParser.Source() = %v, want %v",
+					tt.name, got, tt.wantSrc)
+			}
+		})
+	}
+
+	p := NewParser([]string{`app`}, pattern)
+	if got := p.Count("v"); 2 != got {
+		t.Errorf("TParser.Count() = %d, want %d", got, 2)
+	}
+	if got, ok := p.Lookup("o"); ("out.txt" != got) || !ok {
+		t.Errorf("TParser.Lookup() = %q, %v, want %q, %v", got, ok, "out.txt", true)
+	}
+	if opt, arg, _ := p.Get(); ("p" != opt) || ("9090" != arg) {
+		t.Errorf("TParser.Get() = %q, %q, want %q, %q", opt, arg, "p", "9090")
+	}
+} // TestTParser_defaults()
+
+/* _EoF_ */
@@ -14,6 +14,7 @@ import (
 //lint:file-ignore ST1017 - I prefer Yoda conditions
 
 func Test_realInit(t *testing.T) {
+	defer realInit(gParser.args)
 	a1 := []string{
 		`/path/appname`,
 	}
@@ -36,7 +37,7 @@ func Test_realInit(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			realInit(tt.args)
-			if nil == gIterator {
+			if nil == gParser {
 				t.Errorf("%q: realInit() = %v, want %v",
 					tt.name, nil, "not NIL")
 			}
@@ -45,13 +46,15 @@ func Test_realInit(t *testing.T) {
 } // Test_realInit()
 
 func TestGet(t *testing.T) {
-	// Set getopts `init()` :: []string{
-	// 	"testingApplication",
-	// 	`-a`, // Flag option
-	// 	`-i`, // Error: expected with argument => skipped
-	// 	`--infile`, `config.in`,
-	// 	`--help`, // Flag option
-	// }
+	defer realInit(gParser.args)
+	realInit([]string{
+		"testingApplication",
+		`-a`, // Flag option
+		`-i`, // Error: expected with argument => skipped
+		`--infile`, `config.in`,
+		`--help`, // Flag option
+	})
+
 	p1 := ""
 	o1 := "-help"
 	a1 := TArg("")